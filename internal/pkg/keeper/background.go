@@ -0,0 +1,96 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+)
+
+// periodicTask is a task run in the background at a fixed interval until canceled
+type periodicTask struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func startPeriodicTask(clk clock.Clock, interval time.Duration, run func(ctx context.Context)) *periodicTask {
+	ctx, cancel := context.WithCancel(context.Background())
+	task := &periodicTask{cancel: cancel, done: make(chan struct{})}
+
+	go func() {
+		defer close(task.done)
+
+		ticker := clk.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C():
+				run(ctx)
+			}
+		}
+	}()
+
+	return task
+}
+
+// startBackgroundTasks (re)starts the heartbeat and the reconciliation of the registration of the current service,
+// each at its configured interval, if any. Must be called with the lock held.
+func (k *keeperClient) startBackgroundTasks() {
+	// The intervals have been validated when creating the client
+	heartbeatInterval, _ := k.config.GetHeartbeatInterval()
+	reconcileInterval, _ := k.config.GetReconcileInterval()
+	clk := k.config.GetClock()
+
+	k.tasksLock.Lock()
+	defer k.tasksLock.Unlock()
+
+	k.cancelBackgroundTasks()
+	if heartbeatInterval > 0 {
+		k.heartbeat = startPeriodicTask(clk, heartbeatInterval, k.sendHeartbeat)
+	}
+	if reconcileInterval > 0 {
+		k.reconciler = startPeriodicTask(clk, reconcileInterval, k.reconcile)
+	}
+}
+
+// stopBackgroundTasks stops the background tasks without waiting for an in-flight run, so it is safe to call with
+// the lock held
+func (k *keeperClient) stopBackgroundTasks() {
+	k.tasksLock.Lock()
+	defer k.tasksLock.Unlock()
+
+	k.cancelBackgroundTasks()
+}
+
+// cancelBackgroundTasks must be called with the tasks lock held
+func (k *keeperClient) cancelBackgroundTasks() {
+	for _, task := range []**periodicTask{&k.heartbeat, &k.reconciler} {
+		if *task != nil {
+			(*task).cancel()
+			*task = nil
+		}
+	}
+}
+
+// Stop stops the background tasks of the current service, i.e. the heartbeat and the reconciliation of its
+// registration, waiting for in-flight runs to complete
+func (k *keeperClient) Stop() {
+	k.tasksLock.Lock()
+	tasks := []*periodicTask{k.heartbeat, k.reconciler}
+	k.cancelBackgroundTasks()
+	k.tasksLock.Unlock()
+
+	for _, task := range tasks {
+		if task != nil {
+			<-task.done
+		}
+	}
+}
@@ -7,9 +7,18 @@ package keeper
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	httpClient "github.com/edgexfoundry/go-mod-core-contracts/v4/clients/http"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces"
@@ -17,12 +26,21 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
 	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/responses"
+	edgexErrors "github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 
 	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
 )
 
+// supportedCheckTypes are the health check types Keeper is able to perform
+var supportedCheckTypes = []string{"http", "https"}
+
 type keeperClient struct {
+	// lock guards the settings below against Reconfigure
+	lock       sync.RWMutex
+	registered atomic.Bool
+
 	config              *types.Config
 	keeperUrl           string
 	serviceKey          string
@@ -30,17 +48,50 @@ type keeperClient struct {
 	servicePort         int
 	healthCheckRoute    string
 	healthCheckInterval string
+	healthCheckType     string
+	// checkId is the ID of the health check registered with RegisterCheck in place of the configured one, if any
+	checkId string
 
-	commonClient   interfaces.CommonClient
+	commonClient   *commonClient
 	registryClient interfaces.RegistryClient
+	injector       *transportInjector
+	stats          *clientStats
+
+	// tasksLock guards the background tasks below
+	tasksLock  sync.Mutex
+	heartbeat  *periodicTask
+	reconciler *periodicTask
 }
 
 // NewKeeperClient creates new Keeper Client. Service details are optional, not needed just for configuration, but required if registering
 func NewKeeperClient(registryConfig types.Config) (*keeperClient, error) {
+	if err := registryConfig.EndpointOrder.Validate(); err != nil {
+		return nil, fmt.Errorf("unable to create Keeper client: %v", err)
+	}
+	if _, err := registryConfig.GetWatchInterval(); err != nil {
+		return nil, fmt.Errorf("unable to create Keeper client: %v", err)
+	}
+	if _, err := registryConfig.GetCheckGracePeriod(); err != nil {
+		return nil, fmt.Errorf("unable to create Keeper client: %v", err)
+	}
+	if _, err := registryConfig.GetHeartbeatInterval(); err != nil {
+		return nil, fmt.Errorf("unable to create Keeper client: %v", err)
+	}
+	if _, err := registryConfig.GetReconcileInterval(); err != nil {
+		return nil, fmt.Errorf("unable to create Keeper client: %v", err)
+	}
+	if err := registryConfig.ValidateNamespace(); err != nil {
+		return nil, fmt.Errorf("unable to create Keeper client: %v", err)
+	}
+	if err := registryConfig.ValidateInstanceId(); err != nil {
+		return nil, fmt.Errorf("unable to create Keeper client: %v", err)
+	}
+
 	client := keeperClient{
 		config:     &registryConfig,
-		serviceKey: registryConfig.ServiceKey,
+		serviceKey: registryConfig.GetInstanceKey(),
 		keeperUrl:  registryConfig.GetRegistryUrl(),
+		stats:      &clientStats{},
 	}
 
 	// ServiceHost will be empty when client isn't registering the service
@@ -49,50 +100,239 @@ func NewKeeperClient(registryConfig types.Config) (*keeperClient, error) {
 		client.serviceHost = registryConfig.ServiceHost
 		client.healthCheckRoute = registryConfig.CheckRoute
 		client.healthCheckInterval = registryConfig.CheckInterval
+		client.healthCheckType = registryConfig.GetCheckType()
 	}
 
-	// Create the common and registry http clients for invoking APIs from Keeper
-	client.commonClient = httpClient.NewCommonClient(client.keeperUrl, registryConfig.AuthInjector)
-	client.registryClient = httpClient.NewRegistryClient(client.keeperUrl, registryConfig.AuthInjector, registryConfig.EnableNameFieldEscape)
+	injector, err := newTransportInjector(registryConfig, client.stats)
+	if err != nil {
+		return nil, err
+	}
+	client.injector = injector
+
+	// Create the common and registry http clients for invoking APIs from Keeper, with every call going through the invoker
+	keeperInvoker := newInvoker(client.config, injector, client.stats)
+	client.commonClient = &commonClient{
+		invoker: keeperInvoker,
+		client:  httpClient.NewCommonClient(client.keeperUrl, injector),
+	}
+	client.registryClient = &registryClient{
+		invoker: keeperInvoker,
+		client:  httpClient.NewRegistryClient(client.keeperUrl, injector, registryConfig.EnableNameFieldEscape),
+	}
 
 	return &client, nil
 }
 
 // IsAlive simply checks if Keeper is up and running at the configured URL
 func (k *keeperClient) IsAlive() bool {
-	if _, err := k.commonClient.Ping(context.Background()); err != nil {
-		return false
+	status, _ := k.Status()
+	return status.Alive
+}
+
+// Status checks if Keeper is up and running at the configured URL, returning an error describing why if it isn't.
+// The status is returned in both cases.
+func (k *keeperClient) Status() (types.AliveStatus, error) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	latency, err := k.commonClient.timedPing(context.Background())
+	status := types.AliveStatus{Latency: latency}
+	if err == nil {
+		status.Alive = true
+		status.Reachable = true
+		status.StatusCode = http.StatusOK
+		return status, nil
+	}
+
+	// The request never got a response when the HTTP client failed
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		status.TLSError = tlsError(err)
+		return status, fmt.Errorf("keeper at %s is unreachable: %w", k.keeperUrl, err)
+	}
+
+	status.Reachable = true
+	status.StatusCode = err.Code()
+	return status, fmt.Errorf("keeper at %s responded with status %d: %w", k.keeperUrl, status.StatusCode, wrapError(err))
+}
+
+// MeasureLatency pings Keeper the specified number of times, one after the other, and reports the statistics of the
+// round-trip times. An error is returned when none of the pings got a response.
+func (k *keeperClient) MeasureLatency(samples int) (types.LatencyReport, error) {
+	if samples <= 0 {
+		return types.LatencyReport{}, fmt.Errorf("number of samples must be positive, got %d", samples)
+	}
+
+	latencies := make([]time.Duration, 0, samples)
+	var lastErr error
+	for i := 0; i < samples; i++ {
+		status, err := k.Status()
+		if !status.Reachable {
+			lastErr = err
+			continue
+		}
+		latencies = append(latencies, status.Latency)
 	}
-	return true
+
+	report := types.NewLatencyReport(latencies, samples-len(latencies))
+	if len(latencies) == 0 {
+		return report, fmt.Errorf("none of the %d pings got a response: %w", samples, lastErr)
+	}
+
+	return report, nil
+}
+
+// ClientStats returns the connectivity of the client to Keeper, as observed from the calls made so far
+func (k *keeperClient) ClientStats() types.ClientStats {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	return k.stats.snapshot()
+}
+
+// UnderlyingClient returns the core-contracts RegistryClient used to call Keeper, which applies the Namespace along with
+// the retries, rate limits and metrics of the client. The returned client isn't updated by Reconfigure.
+func (k *keeperClient) UnderlyingClient() any {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	return k.registryClient
+}
+
+// GetRegistryInfo retrieves the version of Keeper, along with the details of how the client connects to it
+func (k *keeperClient) GetRegistryInfo() (types.RegistryInfo, error) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	resp, err := k.commonClient.Version(context.Background())
+	if err != nil {
+		return types.RegistryInfo{}, fmt.Errorf("failed to get the Keeper version: %w", wrapError(err))
+	}
+
+	authMode := types.AuthModeNone
+	switch {
+	case k.config.AccessTokenFile != "" || k.config.GetAccessToken != nil:
+		authMode = types.AuthModeToken
+	case k.config.AuthInjector != nil:
+		authMode = types.AuthModeInjector
+	}
+
+	return types.RegistryInfo{
+		Type:        backendType,
+		Url:         k.keeperUrl,
+		ServiceName: resp.ServiceName,
+		Version:     resp.Version,
+		ApiVersion:  resp.ApiVersion,
+		AuthMode:    authMode,
+	}, nil
 }
 
 // Register registers the current service with Keeper for discovery and health check
-func (k *keeperClient) Register() error {
-	if k.serviceKey == "" || k.serviceHost == "" || k.servicePort == 0 ||
-		k.healthCheckRoute == "" || k.healthCheckInterval == "" {
-		return fmt.Errorf("unable to register service with keeper: Service information not set")
+func (k *keeperClient) Register(options ...types.RegisterOption) error {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	if types.NewRegisterOptions(options...).DryRun {
+		return k.dryRunRegister()
 	}
 
-	registrationReq := requests.AddRegistrationRequest{
-		BaseRequest: dtoCommon.BaseRequest{
-			Versionable: dtoCommon.Versionable{ApiVersion: common.ApiVersion},
-		},
-		Registration: dtos.Registration{
-			ServiceId: k.serviceKey,
-			Host:      k.serviceHost,
-			Port:      k.servicePort,
-			HealthCheck: dtos.HealthCheck{
-				Interval: k.healthCheckInterval,
-				Path:     k.healthCheckRoute,
-				Type:     "http",
-			},
-		},
+	return k.audit("Register", k.serviceKey, k.register())
+}
+
+// dryRunRegister validates the registration of the current service, resolves the URL Keeper will check its health on
+// and checks the access to Keeper, without registering the service. Keeper has no permission preflight, so the access
+// is checked by reading the current registration, which fails the same way as registering without a valid token.
+func (k *keeperClient) dryRunRegister() error {
+	if err := k.validateSelfRegistration(); err != nil {
+		return fmt.Errorf("dry run: unable to register service with keeper: %w", err)
+	}
+
+	// The registration details may have been updated since the client was created
+	checkConfig := *k.config
+	checkConfig.ServiceHost = k.serviceHost
+	checkConfig.ServicePort = k.servicePort
+	checkConfig.CheckRoute = k.healthCheckRoute
+	checkConfig.CheckType = k.healthCheckType
+	checkUrl, err := url.Parse(checkConfig.GetHealthCheckUrl())
+	if err != nil {
+		return fmt.Errorf("dry run: invalid health check URL: %v", err)
+	}
+
+	_, edgexErr := k.registryClient.RegistrationByServiceId(context.Background(), k.serviceKey)
+	if edgexErr != nil && edgexErr.Code() != http.StatusNotFound {
+		return fmt.Errorf("dry run: failed to check the %s service registry status: %w", k.serviceKey, wrapError(edgexErr))
+	}
+
+	k.config.GetLogger().Infof("Dry run: the %s service can be registered with Keeper, with its health checked on %s", k.serviceKey, checkUrl)
+	return nil
+}
+
+func (k *keeperClient) register() error {
+	if err := k.validateSelfRegistration(); err != nil {
+		return fmt.Errorf("unable to register service with keeper: %w", err)
+	}
+
+	if err := k.registerService(k.selfRegistration()); err != nil {
+		return err
+	}
+
+	k.registered.Store(true)
+	k.startBackgroundTasks()
+	k.config.GetLogger().Debugf("Registered the %s service with Keeper", k.serviceKey)
+	return nil
+}
+
+// RegisterService registers any service with Keeper on its behalf, e.g. a sidecar process which can't register itself.
+// The registration is updated if the service is already registered.
+func (k *keeperClient) RegisterService(registration types.ServiceRegistration) error {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	if err := k.audit("RegisterService", registration.GetInstanceKey(), k.registerService(registration)); err != nil {
+		return err
+	}
+
+	k.config.GetLogger().Debugf("Registered the %s service with Keeper", registration.GetInstanceKey())
+	return nil
+}
+
+// RegisterAll registers the specified services with Keeper, e.g. when a single process hosts multiple logical services.
+// Keeper has no bulk registration API, so the services are registered one by one. All services are attempted, and
+// the failed ones are reported with a *types.BatchRegistrationError.
+func (k *keeperClient) RegisterAll(registrations []types.ServiceRegistration) error {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	failed := make(map[string]error)
+	for _, registration := range registrations {
+		if err := k.audit("RegisterAll", registration.GetInstanceKey(), k.registerService(registration)); err != nil {
+			failed[registration.GetInstanceKey()] = err
+			continue
+		}
+
+		k.config.GetLogger().Debugf("Registered the %s service with Keeper", registration.GetInstanceKey())
 	}
 
+	if len(failed) > 0 {
+		return &types.BatchRegistrationError{Failed: failed}
+	}
+
+	return nil
+}
+
+// registerService creates the registration of the service in Keeper, or updates it if the service is already registered
+func (k *keeperClient) registerService(registration types.ServiceRegistration) error {
+	if err := k.validateRegistration(registration); err != nil {
+		return fmt.Errorf("unable to register service with keeper: %w", err)
+	}
+
+	registrationReq := registrationRequest(registration, "")
+	serviceId := registrationReq.Registration.ServiceId
+
 	// check if the service registry exists first
-	resp, err := k.registryClient.RegistrationByServiceId(context.Background(), k.serviceKey)
+	resp, err := k.registryClient.RegistrationByServiceId(context.Background(), serviceId)
 	if err != nil && err.Code() != http.StatusNotFound {
-		return fmt.Errorf("failed to check the %s service registry status: %v", k.serviceKey, err)
+		return fmt.Errorf("failed to check the %s service registry status: %w", serviceId, wrapError(err))
 	}
 
 	// call the UpdateRegister to update the registry if the service already exists
@@ -100,114 +340,678 @@ func (k *keeperClient) Register() error {
 	if resp.StatusCode == http.StatusOK {
 		err := k.registryClient.UpdateRegister(context.Background(), registrationReq)
 		if err != nil {
-			return fmt.Errorf("failed to update the %s service registry: %v", k.serviceKey, err)
+			return fmt.Errorf("failed to update the %s service registry: %w", serviceId, wrapError(err))
 		}
 	} else {
 		err := k.registryClient.Register(context.Background(), registrationReq)
 		if err != nil {
-			return fmt.Errorf("failed to register the %s service: %v", k.serviceKey, err)
+			return fmt.Errorf("failed to register the %s service: %w", serviceId, wrapError(err))
 		}
 	}
 
 	return nil
 }
 
-// RegisterCheck registers a health check with Keeper
-func (k *keeperClient) RegisterCheck(id string, name string, notes string, url string, interval string) error {
-	// keeper combines service discovery and health check into one single register request
+// UpdateRegister updates the existing registration of the current service in Keeper with the current service details,
+// keeping its creation time and status, rather than having to de-register and register again
+func (k *keeperClient) UpdateRegister() error {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	return k.audit("UpdateRegister", k.serviceKey, k.updateRegister())
+}
+
+func (k *keeperClient) updateRegister() error {
+	if err := k.validateSelfRegistration(); err != nil {
+		return fmt.Errorf("unable to update service registration with keeper: %w", err)
+	}
+
+	err := k.updateInPlace(k.selfRegistration())
+	if err != nil {
+		return fmt.Errorf("failed to update the %s service registry: %w", k.serviceKey, wrapError(err))
+	}
+
+	k.registered.Store(true)
+	k.startBackgroundTasks()
 	return nil
 }
 
-func (k *keeperClient) UnregisterCheck(id string) error {
-	// keeper combines service discovery and health check into one single register request
+// UpdateRegistration changes the host and port the current service is registered with, e.g. after it rebound following
+// a network change. When registered, the registration is updated in place, keeping its creation time and status, so the
+// service doesn't go through an unregistered state. Otherwise the new details are used by the next registration.
+func (k *keeperClient) UpdateRegistration(newHost string, newPort int) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	return k.audit("UpdateRegistration", k.serviceKey, k.updateRegistration(newHost, newPort))
+}
+
+func (k *keeperClient) updateRegistration(newHost string, newPort int) error {
+	updatedConfig := *k.config
+	updatedConfig.ServiceHost = newHost
+	updatedConfig.ServicePort = newPort
+
+	registration := k.selfRegistration()
+	registration.Host = newHost
+	registration.Port = newPort
+	if err := k.validateRegistration(registration); err != nil {
+		return fmt.Errorf("unable to update service registration with keeper: %w", err)
+	}
+	if err := validateCheckTarget(updatedConfig); err != nil {
+		return fmt.Errorf("unable to update service registration with keeper: %w", err)
+	}
+
+	if k.registered.Load() {
+		err := k.updateInPlace(registration)
+		if err != nil {
+			return fmt.Errorf("failed to update the %s service registry: %w", k.serviceKey, wrapError(err))
+		}
+	}
+
+	k.config = &updatedConfig
+	k.serviceHost = newHost
+	k.servicePort = newPort
 	return nil
 }
 
-// Unregister de-registers the current service from Keeper
-func (k *keeperClient) Unregister() error {
-	registrationReq := requests.AddRegistrationRequest{
+func (k *keeperClient) validateRegistration(registration types.ServiceRegistration) error {
+	if err := registration.Validate(); err != nil {
+		return err
+	}
+
+	if err := k.config.ServiceKeyPolicy.Validate(registration.ServiceKey); err != nil {
+		return err
+	}
+
+	// Keeper calls the health check route using the check type as the URL scheme
+	if !slices.Contains(supportedCheckTypes, registration.GetCheckType()) {
+		return fmt.Errorf("health check type '%s' is not supported, must be one of %s", registration.GetCheckType(), strings.Join(supportedCheckTypes, ", "))
+	}
+
+	return nil
+}
+
+// validateSelfRegistration validates the registration of the current service, including the settings Keeper doesn't support
+func (k *keeperClient) validateSelfRegistration() error {
+	if err := k.validateRegistration(k.selfRegistration()); err != nil {
+		return err
+	}
+
+	return validateCheckTarget(*k.config)
+}
+
+// validateCheckTarget checks the health check of the current service targets its registered host and port, as Keeper
+// always checks the health of a service on its registered address
+func validateCheckTarget(config types.Config) error {
+	if config.GetCheckHost() != config.ServiceHost {
+		return fmt.Errorf("health check host '%s' different from service host '%s': %w", config.CheckHost, config.ServiceHost, types.ErrUnsupported)
+	}
+	if config.GetCheckPort() != config.ServicePort {
+		return fmt.Errorf("health check port %d different from service port %d: %w", config.CheckPort, config.ServicePort, types.ErrUnsupported)
+	}
+
+	return nil
+}
+
+// selfRegistration returns the registration details of the current service
+func (k *keeperClient) selfRegistration() types.ServiceRegistration {
+	serviceKey, instanceId := types.SplitInstanceKey(k.serviceKey)
+	return types.ServiceRegistration{
+		ServiceKey:    serviceKey,
+		InstanceId:    instanceId,
+		Host:          k.serviceHost,
+		Port:          k.servicePort,
+		CheckRoute:    k.healthCheckRoute,
+		CheckInterval: k.healthCheckInterval,
+		CheckType:     k.healthCheckType,
+	}
+}
+
+// updateInPlace updates the existing registration of the service in Keeper, carrying over the status Keeper currently
+// holds for it, as Keeper would otherwise reset the status to UNKNOWN until the next health check
+func (k *keeperClient) updateInPlace(registration types.ServiceRegistration) edgexErrors.EdgeX {
+	resp, err := k.registryClient.RegistrationByServiceId(context.Background(), registration.GetInstanceKey())
+	if err != nil && err.Code() != http.StatusNotFound {
+		return err
+	}
+
+	// The update itself reports the registration as not found
+	return k.registryClient.UpdateRegister(context.Background(), registrationRequest(registration, resp.Registration.Status))
+}
+
+// registrationRequest builds the registration request for the service with the specified status
+func registrationRequest(registration types.ServiceRegistration, status string) requests.AddRegistrationRequest {
+	return requests.AddRegistrationRequest{
 		BaseRequest: dtoCommon.BaseRequest{
 			Versionable: dtoCommon.Versionable{ApiVersion: common.ApiVersion},
 		},
 		Registration: dtos.Registration{
-			ServiceId: k.serviceKey,
-			Host:      k.serviceHost,
-			Port:      k.servicePort,
+			ServiceId: registration.GetInstanceKey(),
+			Host:      registration.Host,
+			Port:      registration.Port,
 			HealthCheck: dtos.HealthCheck{
-				Interval: k.healthCheckInterval,
-				Path:     k.healthCheckRoute,
-				Type:     "http",
+				Interval: registration.CheckInterval,
+				Path:     registration.CheckRoute,
+				Type:     registration.GetCheckType(),
 			},
-			Status: models.Halt,
+			Status: status,
 		},
 	}
+}
+
+// RegisterCheck replaces the health check of the current service, as Keeper performs a single health check per service.
+// The check must target the registered host and port of the service, which is where Keeper checks its health, using
+// one of the supported check types as the URL scheme. Otherwise an error wrapping types.ErrUnsupported is returned.
+// When registered, the registration is updated in place. Otherwise the check is used by the next registration.
+func (k *keeperClient) RegisterCheck(id string, name string, notes string, checkUrl string, interval string) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	return k.audit("RegisterCheck", id, k.registerCheck(id, checkUrl, interval))
+}
+
+func (k *keeperClient) registerCheck(id string, checkUrl string, interval string) error {
+	if k.checkId != "" && k.checkId != id {
+		return fmt.Errorf("unable to register health check %s, keeper already performs health check %s: %w", id, k.checkId, types.ErrUnsupported)
+	}
+
+	parsedUrl, err := url.Parse(checkUrl)
+	if err != nil {
+		return fmt.Errorf("unable to register health check %s: %v", id, err)
+	}
+	if err := k.validateCheckUrl(parsedUrl); err != nil {
+		return fmt.Errorf("unable to register health check %s: %w", id, err)
+	}
+
+	registration := k.selfRegistration()
+	registration.CheckRoute = parsedUrl.Path
+	registration.CheckInterval = interval
+	registration.CheckType = parsedUrl.Scheme
+	if err := k.validateRegistration(registration); err != nil {
+		return fmt.Errorf("unable to register health check %s: %w", id, err)
+	}
+
+	if err := k.updateCheck(registration); err != nil {
+		return err
+	}
+
+	k.checkId = id
+	return nil
+}
+
+// UnregisterCheck restores the configured health check of the current service, replaced by RegisterCheck.
+// Nothing is done for any other check, as Keeper doesn't know about it.
+func (k *keeperClient) UnregisterCheck(id string) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	return k.audit("UnregisterCheck", id, k.unregisterCheck(id))
+}
+
+func (k *keeperClient) unregisterCheck(id string) error {
+	if k.checkId == "" || k.checkId != id {
+		return nil
+	}
+
+	registration := k.selfRegistration()
+	registration.CheckRoute = k.config.CheckRoute
+	registration.CheckInterval = k.config.CheckInterval
+	registration.CheckType = k.config.GetCheckType()
+	if err := k.updateCheck(registration); err != nil {
+		return err
+	}
+
+	k.checkId = ""
+	return nil
+}
+
+// validateCheckUrl checks the health check URL uses a supported check type and targets the registered host and port
+// of the current service
+func (k *keeperClient) validateCheckUrl(checkUrl *url.URL) error {
+	if !slices.Contains(supportedCheckTypes, checkUrl.Scheme) {
+		return fmt.Errorf("health check type '%s' is not one of %s: %w", checkUrl.Scheme, strings.Join(supportedCheckTypes, ", "), types.ErrUnsupported)
+	}
+
+	port := checkUrl.Port()
+	if port == "" {
+		switch checkUrl.Scheme {
+		case "http":
+			port = "80"
+		case "https":
+			port = "443"
+		}
+	}
+
+	if checkUrl.Hostname() != strings.Trim(k.serviceHost, "[]") || port != strconv.Itoa(k.servicePort) {
+		return fmt.Errorf("health check URL '%s' must target the service address %s: %w",
+			checkUrl.Redacted(), net.JoinHostPort(strings.Trim(k.serviceHost, "[]"), strconv.Itoa(k.servicePort)), types.ErrUnsupported)
+	}
+
+	return nil
+}
+
+// updateCheck updates the registration of the current service with the health check of the specified registration,
+// if registered, and keeps the check for the next registrations. Must be called with the write lock held.
+func (k *keeperClient) updateCheck(registration types.ServiceRegistration) error {
+	if k.registered.Load() {
+		err := k.updateInPlace(registration)
+		if err != nil {
+			return fmt.Errorf("failed to update the health check of the %s service: %w", k.serviceKey, wrapError(err))
+		}
+	}
+
+	k.healthCheckRoute = registration.CheckRoute
+	k.healthCheckInterval = registration.CheckInterval
+	k.healthCheckType = registration.CheckType
+	return nil
+}
+
+// Unregister de-registers the current service from Keeper
+func (k *keeperClient) Unregister() error {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	return k.audit("Unregister", k.serviceKey, k.unregister())
+}
+
+func (k *keeperClient) unregister() error {
+	k.stopBackgroundTasks()
+
+	registrationReq := registrationRequest(k.selfRegistration(), models.Halt)
 
 	err := k.registryClient.UpdateRegister(context.Background(), registrationReq)
 	if err != nil {
-		return fmt.Errorf("failed to de-register %s: %v", k.serviceKey, err)
+		return fmt.Errorf("failed to de-register %s: %w", k.serviceKey, wrapError(err))
 	}
 
+	k.registered.Store(false)
 	return nil
 }
 
+// UnregisterByServiceId removes the registration of any service from Keeper, e.g. to clean up the stale registration
+// of a service which crashed without de-registering
+func (k *keeperClient) UnregisterByServiceId(serviceKey string) error {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	return k.audit("UnregisterByServiceId", serviceKey, k.unregisterService(serviceKey))
+}
+
+func (k *keeperClient) unregisterService(serviceKey string) error {
+	if err := k.registryClient.Deregister(context.Background(), serviceKey); err != nil {
+		return fmt.Errorf("failed to remove the %s service registration: %w", serviceKey, wrapError(err))
+	}
+
+	if serviceKey == k.serviceKey {
+		k.stopBackgroundTasks()
+		k.registered.Store(false)
+	}
+
+	return nil
+}
+
+// audit records the operation with the configured AuditSink, if any, returning the error of the operation
+func (k *keeperClient) audit(operation string, target string, err error) error {
+	if k.config.AuditSink != nil {
+		k.config.AuditSink.RecordAudit(types.AuditRecord{
+			Actor:     k.serviceKey,
+			Operation: operation,
+			Target:    target,
+			Timestamp: k.config.GetClock().Now(),
+			Err:       err,
+		})
+	}
+
+	return err
+}
+
 // GetServiceEndpoint retrieves the port, service ID and host of a known endpoint from Keeper.
 // If this operation is successful and a known endpoint is found, it is returned. Otherwise, an error is returned.
+// The service key finds any replica of a service registered with an InstanceId, preferring the healthy ones.
 func (k *keeperClient) GetServiceEndpoint(serviceKey string) (types.ServiceEndpoint, error) {
-	resp, err := k.registryClient.RegistrationByServiceId(context.Background(), serviceKey)
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	resp, err := k.registrationByServiceKey(serviceKey)
 	if err != nil {
-		return types.ServiceEndpoint{}, fmt.Errorf("failed to get service %s endpoint: %v", serviceKey, err)
+		return types.ServiceEndpoint{}, fmt.Errorf("failed to get service %s endpoint: %w", serviceKey, wrapError(err))
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return types.ServiceEndpoint{}, fmt.Errorf("failed to get service %s endpoint: %w", serviceKey, types.ErrServiceNotFound)
 	}
 
-	endpoint := types.ServiceEndpoint{
-		ServiceId: serviceKey,
-		Host:      resp.Registration.Host,
-		Port:      resp.Registration.Port,
+	return toServiceEndpoint(resp.Registration), nil
+}
+
+// registrationByServiceKey retrieves the registration of the service from Keeper. A service key which isn't registered
+// as is is resolved against the replicas registered with an InstanceId, preferring the healthy ones, so a scaled service
+// is found by its service key. Must be called with the lock held.
+func (k *keeperClient) registrationByServiceKey(serviceKey string) (responses.RegistrationResponse, edgexErrors.EdgeX) {
+	resp, err := k.registryClient.RegistrationByServiceId(context.Background(), serviceKey)
+	notFound := (err != nil && err.Code() == http.StatusNotFound) || (err == nil && resp.StatusCode == http.StatusNotFound)
+	if _, instanceId := types.SplitInstanceKey(serviceKey); !notFound || instanceId != "" {
+		return resp, err
+	}
+
+	// Keeper has no lookup by service key prefix, so the replicas are found among all the registrations
+	all, allErr := k.registryClient.AllRegistry(context.Background(), false)
+	if allErr != nil {
+		return resp, allErr
+	}
+	sortRegistrations(all.Registrations, types.EndpointOrderHealth, 0)
+	for _, registration := range all.Registrations {
+		if replicaKey, instanceId := types.SplitInstanceKey(registration.ServiceId); replicaKey == serviceKey && instanceId != "" {
+			return responses.NewRegistrationResponse("", "", http.StatusOK, registration), nil
+		}
 	}
 
-	return endpoint, nil
+	return resp, err
 }
 
-// GetAllServiceEndpoints retrieves all registered endpoints from Keeper.
+// GetServiceHealthDetails retrieves the result of the health check of the target service from Keeper, which performs
+// a single health check per service and reports neither its output nor when it last ran
+func (k *keeperClient) GetServiceHealthDetails(serviceKey string) ([]types.HealthCheckResult, error) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	resp, err := k.registrationByServiceKey(serviceKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service %s health details: %w", serviceKey, wrapError(err))
+	}
+
+	healthCheck := resp.Registration.HealthCheck
+	return []types.HealthCheckResult{{
+		Name:     fmt.Sprintf("%s %s", healthCheck.Type, healthCheck.Path),
+		Status:   resp.Registration.Status,
+		Interval: healthCheck.Interval,
+	}}, nil
+}
+
+// GetAllServiceEndpoints retrieves all registered endpoints from Keeper, ordered as configured by EndpointOrder.
+// Registrations returned without host or port are left out of the result, in which case the remaining endpoints
+// are returned along with a *types.PartialResultError.
 func (k *keeperClient) GetAllServiceEndpoints() ([]types.ServiceEndpoint, error) {
 	// filter out registrations with status is HALT which have been deregistered
+	return k.allServiceEndpoints(false, types.ListOptions{}, nil)
+}
+
+// ListServiceEndpoints retrieves the registered endpoints from Keeper, ordered and filtered as specified by the options.
+// Keeper doesn't sort nor filter registrations, so this is done client side.
+func (k *keeperClient) ListServiceEndpoints(options types.ListOptions) ([]types.ServiceEndpoint, error) {
+	if err := options.Order.Validate(); err != nil {
+		return nil, err
+	}
+
+	var matches func(serviceId string) bool
+	if options.ServiceIdFilter != nil {
+		matches = options.ServiceIdFilter.MatchString
+	}
+	return k.allServiceEndpoints(false, options, matches)
+}
+
+// GetServiceEndpointsByStatus retrieves the registered endpoints with the status, e.g. UP or DOWN, from Keeper, ordered as
+// configured by EndpointOrder. The de-registered endpoints are only returned when asking for the HALT status.
+// Keeper doesn't filter registrations by status, so the registrations are filtered client side.
+func (k *keeperClient) GetServiceEndpointsByStatus(status string) ([]types.ServiceEndpoint, error) {
+	endpoints, err := k.allServiceEndpoints(strings.EqualFold(status, models.Halt), types.ListOptions{}, nil)
+	var partialErr *types.PartialResultError
+	if err != nil && !errors.As(err, &partialErr) {
+		return nil, err
+	}
+
+	filtered := make([]types.ServiceEndpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if strings.EqualFold(endpoint.HealthStatus, status) {
+			filtered = append(filtered, endpoint)
+		}
+	}
+
+	return filtered, err
+}
+
+// ExportRegistrations retrieves the registrations of all the services from Keeper, excluding the de-registered ones,
+// ordered by service key. Registrations lacking a host or port are exported as is, so the snapshot matches Keeper.
+func (k *keeperClient) ExportRegistrations() ([]types.ServiceRegistration, error) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
 	resp, err := k.registryClient.AllRegistry(context.Background(), false)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get all service endpoints: %v", err)
+		return nil, fmt.Errorf("failed to export the registrations: %w", wrapError(err))
 	}
 
-	endpoints := make([]types.ServiceEndpoint, len(resp.Registrations))
-	for idx, r := range resp.Registrations {
-		endpoint := types.ServiceEndpoint{
-			ServiceId: r.ServiceId,
-			Host:      r.Host,
-			Port:      r.Port,
+	sortRegistrations(resp.Registrations, types.EndpointOrderServiceId, 0)
+	registrations := make([]types.ServiceRegistration, 0, len(resp.Registrations))
+	for _, r := range resp.Registrations {
+		serviceKey, instanceId := types.SplitInstanceKey(r.ServiceId)
+		registrations = append(registrations, types.ServiceRegistration{
+			ServiceKey:    serviceKey,
+			InstanceId:    instanceId,
+			Host:          r.Host,
+			Port:          r.Port,
+			CheckRoute:    r.HealthCheck.Path,
+			CheckInterval: r.HealthCheck.Interval,
+			CheckType:     r.HealthCheck.Type,
+		})
+	}
+
+	if missing := int(resp.TotalCount) - len(resp.Registrations); missing > 0 {
+		partialErr := &types.PartialResultError{Missing: missing}
+		k.config.GetLogger().Warn(partialErr.Error())
+		return registrations, partialErr
+	}
+
+	return registrations, nil
+}
+
+// FindServiceEndpoints retrieves the registered endpoints whose service ID matches the glob pattern from Keeper, ordered
+// as configured by EndpointOrder. Keeper doesn't filter registrations by service ID, so the registrations are filtered
+// client side.
+func (k *keeperClient) FindServiceEndpoints(pattern string) ([]types.ServiceEndpoint, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid service ID pattern '%s': %v", pattern, err)
+	}
+
+	return k.allServiceEndpoints(false, types.ListOptions{}, func(serviceId string) bool {
+		matched, _ := path.Match(pattern, serviceId)
+		return matched
+	})
+}
+
+// allServiceEndpoints retrieves the registered endpoints, including the de-registered ones if specified, ordered as
+// specified by the options, and only those whose service ID matches, if specified. Keeper doesn't filter registrations
+// by service ID, so the match is evaluated client side. The registrations which don't match are left out before
+// checking they're complete, so they're never reported in a PartialResultError.
+func (k *keeperClient) allServiceEndpoints(deregistered bool, options types.ListOptions, matches func(serviceId string) bool) ([]types.ServiceEndpoint, error) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	resp, err := k.registryClient.AllRegistry(context.Background(), deregistered)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all service endpoints: %w", wrapError(err))
+	}
+
+	order := options.Order
+	if order == "" {
+		order = k.config.EndpointOrder
+	}
+	sortRegistrations(resp.Registrations, order, k.config.EndpointOrderSeed)
+	if options.Descending {
+		slices.Reverse(resp.Registrations)
+	}
+
+	var incomplete []string
+	endpoints := make([]types.ServiceEndpoint, 0, len(resp.Registrations))
+	for _, r := range resp.Registrations {
+		if serviceId, _ := types.SplitInstanceKey(r.ServiceId); matches != nil && !matches(serviceId) {
+			continue
+		}
+		if r.Host == "" || r.Port == 0 {
+			incomplete = append(incomplete, r.ServiceId)
+			continue
 		}
-		endpoints[idx] = endpoint
+
+		endpoints = append(endpoints, toServiceEndpoint(r))
+	}
+
+	missing := int(resp.TotalCount) - len(resp.Registrations)
+	if len(incomplete) > 0 || missing > 0 {
+		partialErr := &types.PartialResultError{Incomplete: incomplete, Missing: max(missing, 0)}
+		k.config.GetLogger().Warn(partialErr.Error())
+		return endpoints, partialErr
 	}
 
 	return endpoints, nil
 }
 
+// GetAllServiceEndpointsPaged retrieves a page of the registered endpoints from Keeper, ordered as configured by
+// EndpointOrder, along with the total number of endpoints. A negative limit returns all the endpoints from the offset.
+// Keeper doesn't page registrations, so all of them are retrieved and the page is taken client side, which keeps the
+// pages stable as every ordering is deterministic.
+func (k *keeperClient) GetAllServiceEndpointsPaged(offset int, limit int) ([]types.ServiceEndpoint, int, error) {
+	if offset < 0 {
+		return nil, 0, fmt.Errorf("invalid offset %d: must not be negative", offset)
+	}
+
+	endpoints, err := k.GetAllServiceEndpoints()
+	var partialErr *types.PartialResultError
+	if err != nil && !errors.As(err, &partialErr) {
+		return nil, 0, err
+	}
+
+	total := len(endpoints)
+	start := min(offset, total)
+	end := total
+	if limit >= 0 {
+		end = min(start+limit, total)
+	}
+
+	return endpoints[start:end], total, err
+}
+
+// toServiceEndpoint converts the Keeper registration to the service endpoint. Keeper calls the health check using
+// the check type as the scheme, so it is also the protocol of the service.
+func toServiceEndpoint(registration dtos.Registration) types.ServiceEndpoint {
+	serviceKey, instanceId := types.SplitInstanceKey(registration.ServiceId)
+	endpoint := types.ServiceEndpoint{
+		ServiceId:    serviceKey,
+		InstanceId:   instanceId,
+		Host:         registration.Host,
+		Port:         registration.Port,
+		Protocol:     registration.HealthCheck.Type,
+		HealthStatus: registration.Status,
+	}
+
+	// Keeper timestamps are in milliseconds
+	if updated := lastUpdated(registration); updated != 0 {
+		endpoint.LastUpdated = time.UnixMilli(updated)
+	}
+
+	return endpoint
+}
+
 // IsServiceAvailable checks with Keeper if the target service is registered and healthy
 func (k *keeperClient) IsServiceAvailable(serviceKey string) (bool, error) {
-	resp, err := k.registryClient.RegistrationByServiceId(context.Background(), serviceKey)
-	if err != nil && err.Code() != http.StatusNotFound {
-		return false, fmt.Errorf("failed to get %s service registry: %v", serviceKey, err)
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	resp, err := k.registrationByServiceKey(serviceKey)
+	statusCode := resp.StatusCode
+	if err != nil {
+		if err.Code() != http.StatusNotFound {
+			return false, fmt.Errorf("failed to get %s service registry: %w", serviceKey, wrapError(err))
+		}
+		statusCode = http.StatusNotFound
 	}
 
-	switch resp.StatusCode {
+	switch statusCode {
 	case http.StatusOK:
 		if strings.EqualFold(resp.Registration.Status, models.Halt) {
-			return false, fmt.Errorf(" %s service has been unregistered", serviceKey)
+			return false, fmt.Errorf("%s service has been unregistered: %w", serviceKey, types.ErrNotRegistered)
 		}
 		if !strings.EqualFold(resp.Registration.Status, "up") {
-			return false, fmt.Errorf(" %s service not healthy...", serviceKey)
+			if k.inGracePeriod(resp.Registration) {
+				return false, fmt.Errorf("%s service not healthy yet: %w", serviceKey, types.ErrServiceStarting)
+			}
+			return false, fmt.Errorf("%s service not healthy: %w", serviceKey, types.ErrServiceNotHealthy)
 		}
 
 		return true, nil
 	case http.StatusNotFound:
-		return false, fmt.Errorf("%s service is not registered. Might not have started... ", serviceKey)
+		return false, fmt.Errorf("%s service is not registered. Might not have started: %w", serviceKey, types.ErrNotRegistered)
 	default:
 		return false, fmt.Errorf("failed to check service availability: %s", resp.Message)
 	}
 }
+
+// inGracePeriod returns true when the registration was created within the configured health check grace period.
+// Keeper has no notion of a grace period, so it is applied client side based on when Keeper created the registration.
+func (k *keeperClient) inGracePeriod(registration dtos.Registration) bool {
+	gracePeriod, _ := k.config.GetCheckGracePeriod()
+	if gracePeriod == 0 || registration.Created == 0 {
+		return false
+	}
+
+	// Keeper timestamps are in milliseconds
+	return k.config.GetClock().Since(time.UnixMilli(registration.Created)) < gracePeriod
+}
+
+// Reconfigure applies the new configuration in place. Keeper is only contacted when the current service is registered
+// and its registration details changed, in which case the registration is updated, or moved if the service key changed.
+func (k *keeperClient) Reconfigure(registryConfig types.Config) error {
+	updated, err := NewKeeperClient(registryConfig)
+	if err != nil {
+		return err
+	}
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	keyChanged := k.serviceKey != updated.serviceKey || k.config.Namespace != updated.config.Namespace
+	registrationChanged := keyChanged ||
+		k.serviceHost != updated.serviceHost ||
+		k.servicePort != updated.servicePort ||
+		k.healthCheckRoute != updated.healthCheckRoute ||
+		k.healthCheckInterval != updated.healthCheckInterval ||
+		k.healthCheckType != updated.healthCheckType
+	reRegister := k.registered.Load() && registrationChanged
+
+	// The previous registration must not be left behind when the service key or namespace changes
+	if reRegister && keyChanged {
+		if err := k.audit("Unregister", k.serviceKey, k.unregister()); err != nil {
+			return fmt.Errorf("failed to reconfigure: %v", err)
+		}
+	}
+
+	k.config = updated.config
+	k.keeperUrl = updated.keeperUrl
+	k.serviceKey = updated.serviceKey
+	k.serviceHost = updated.serviceHost
+	k.servicePort = updated.servicePort
+	k.healthCheckRoute = updated.healthCheckRoute
+	k.healthCheckInterval = updated.healthCheckInterval
+	k.healthCheckType = updated.healthCheckType
+	// The configured health check is restored in place of the one registered with RegisterCheck, if any
+	k.checkId = ""
+	k.commonClient = updated.commonClient
+	k.registryClient = updated.registryClient
+	updated.stats.restore(k.stats)
+	k.stats = updated.stats
+	// The access token renewed so far stays valid, so it isn't obtained again with the first request
+	if updated.config.GetAccessToken != nil {
+		updated.injector.restoreAccessToken(k.injector)
+	}
+	k.injector = updated.injector
+
+	if reRegister {
+		k.config.GetLogger().Infof("Registration details changed, re-registering the %s service with Keeper", k.serviceKey)
+		if err := k.audit("Register", k.serviceKey, k.register()); err != nil {
+			return fmt.Errorf("failed to reconfigure: %v", err)
+		}
+	} else if k.registered.Load() {
+		// Picks up changed heartbeat and reconcile intervals
+		k.startBackgroundTasks()
+	}
+
+	return nil
+}
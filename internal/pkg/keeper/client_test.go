@@ -6,19 +6,37 @@
 package keeper
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/keepertest"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/retry"
 	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
 )
 
@@ -34,12 +52,12 @@ var (
 	testRegistryPort = 0
 )
 
-var mockKeeper *MockKeeper
+var mockKeeper *keepertest.MockKeeper
 
 func TestMain(m *testing.M) {
 	var testMockServer *httptest.Server
 	if testRegistryHost == "" || testRegistryPort != 59883 {
-		mockKeeper = NewMockKeeper()
+		mockKeeper = keepertest.NewMockKeeper()
 		testMockServer = mockKeeper.Start()
 
 		URL, _ := url.Parse(testMockServer.URL)
@@ -61,6 +79,40 @@ func TestIsAlive(t *testing.T) {
 	}
 }
 
+func TestGetRegistryInfo(t *testing.T) {
+	if mockKeeper == nil {
+		t.Skip("requires the mock Keeper to know the version")
+	}
+
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+
+	info, err := client.GetRegistryInfo()
+	require.NoError(t, err)
+	require.Equal(t, "keeper", info.Type)
+	require.Equal(t, client.keeperUrl, info.Url)
+	require.Equal(t, common.CoreKeeperServiceKey, info.ServiceName)
+	require.Equal(t, keepertest.Version, info.Version)
+	require.Equal(t, common.ApiVersion, info.ApiVersion)
+	require.Equal(t, types.AuthModeInjector, info.AuthMode)
+}
+
+func TestUnderlyingClient(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+
+	// Try to clean-up after test
+	defer func() {
+		_ = client.Unregister()
+	}()
+
+	require.NoError(t, client.Register())
+
+	underlying, ok := client.UnderlyingClient().(interfaces.RegistryClient)
+	require.True(t, ok)
+	resp, err := underlying.RegistrationByServiceId(context.Background(), client.serviceKey)
+	require.NoError(t, err)
+	require.Equal(t, client.serviceKey, resp.Registration.ServiceId)
+}
+
 func TestRegisterNoServiceInfoError(t *testing.T) {
 	// Don't set the service info so check for info results in error
 	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, false)
@@ -83,7 +135,10 @@ func TestRegisterWithPingCallback(t *testing.T) {
 				writer.Header().Set(common.ContentType, common.ContentTypeText)
 				_, _ = writer.Write([]byte("pong"))
 
-				doneChan <- true
+				select {
+				case doneChan <- true:
+				default:
+				}
 			}
 		}
 	}))
@@ -105,12 +160,10 @@ func TestRegisterWithPingCallback(t *testing.T) {
 	err := client.Register()
 	require.NoError(t, err)
 
-	go func() {
-		time.Sleep(10 * time.Second)
-		doneChan <- false
-	}()
-
-	<-doneChan
+	select {
+	case <-doneChan:
+	case <-time.After(watchTimeout):
+	}
 	require.True(t, receivedPing, "Never received health check ping")
 }
 
@@ -135,6 +188,72 @@ func TestDuplicateRegister(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestUpdateRegister(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+
+	// Try to clean-up after test
+	defer func() {
+		_ = client.Unregister()
+	}()
+
+	err := client.Register()
+	require.NoError(t, err)
+	if mockKeeper != nil {
+		require.True(t, mockKeeper.SetStatus(client.serviceKey, models.Up))
+	}
+	original, edgexErr := client.registryClient.RegistrationByServiceId(context.Background(), client.serviceKey)
+	require.NoError(t, edgexErr)
+
+	client.servicePort = defaultServicePort + 1
+	client.config.ServicePort = client.servicePort
+	client.healthCheckInterval = "5s"
+	err = client.UpdateRegister()
+	require.NoError(t, err)
+
+	updated, edgexErr := client.registryClient.RegistrationByServiceId(context.Background(), client.serviceKey)
+	require.NoError(t, edgexErr)
+	require.Equal(t, client.servicePort, updated.Registration.Port)
+	require.Equal(t, "5s", updated.Registration.HealthCheck.Interval)
+	require.Equal(t, original.Registration.Created, updated.Registration.Created)
+	require.Equal(t, original.Registration.Status, updated.Registration.Status)
+}
+
+func TestUpdateRegistration(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+
+	// Try to clean-up after test
+	defer func() {
+		_ = client.Unregister()
+	}()
+
+	require.NoError(t, client.Register())
+	if mockKeeper != nil {
+		require.True(t, mockKeeper.SetStatus(client.serviceKey, models.Up))
+	}
+	before, edgexErr := client.registryClient.RegistrationByServiceId(context.Background(), client.serviceKey)
+	require.NoError(t, edgexErr)
+
+	err := client.UpdateRegistration("127.0.0.1", defaultServicePort+1)
+	require.NoError(t, err)
+
+	after, edgexErr := client.registryClient.RegistrationByServiceId(context.Background(), client.serviceKey)
+	require.NoError(t, edgexErr)
+	require.Equal(t, "127.0.0.1", after.Registration.Host)
+	require.Equal(t, defaultServicePort+1, after.Registration.Port)
+	require.Equal(t, before.Registration.Created, after.Registration.Created)
+	require.Equal(t, before.Registration.Status, after.Registration.Status)
+	require.True(t, client.registered.Load())
+
+	// Invalid details leave the registration untouched
+	err = client.UpdateRegistration("", defaultServicePort)
+	require.Error(t, err)
+	require.Equal(t, "127.0.0.1", client.serviceHost)
+
+	client.config.CheckPort = defaultServicePort + 1
+	err = client.UpdateRegistration("127.0.0.1", defaultServicePort+2)
+	require.Error(t, err)
+}
+
 func TestUnregister(t *testing.T) {
 	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
 
@@ -151,12 +270,33 @@ func TestUnregister(t *testing.T) {
 	require.NoError(t, err, "Expected no error since service registry still exists after un-registering")
 }
 
+func TestUnregisterByServiceId(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	other := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort+1, true)
+
+	require.NoError(t, client.Register())
+	require.NoError(t, other.Register())
+
+	err := client.UnregisterByServiceId(other.serviceKey)
+	require.NoError(t, err)
+	_, err = client.GetServiceEndpoint(other.serviceKey)
+	require.ErrorIs(t, err, types.ErrServiceNotFound, "the registration should have been removed rather than de-registered")
+	require.True(t, client.registered.Load())
+
+	err = client.UnregisterByServiceId(client.serviceKey)
+	require.NoError(t, err)
+	require.False(t, client.registered.Load())
+}
+
 func TestGetServiceEndpoint(t *testing.T) {
 	uniqueServiceName := getUniqueServiceName()
-	expectedFoundEndpoint := types.ServiceEndpoint{
-		ServiceId: uniqueServiceName,
-		Host:      defaultServiceHost,
-		Port:      defaultServicePort,
+	requireEndpoint := func(t *testing.T, actual types.ServiceEndpoint, status string) {
+		require.Equal(t, uniqueServiceName, actual.ServiceId)
+		require.Equal(t, defaultServiceHost, actual.Host)
+		require.Equal(t, defaultServicePort, actual.Port)
+		require.Equal(t, "http", actual.Protocol)
+		require.Equal(t, status, actual.HealthStatus)
+		require.False(t, actual.LastUpdated.IsZero())
 	}
 
 	client := makeKeeperClient(t, uniqueServiceName, defaultServiceHost, defaultServicePort, true)
@@ -172,7 +312,7 @@ func TestGetServiceEndpoint(t *testing.T) {
 	actualEndpoint, err := client.GetServiceEndpoint(client.serviceKey)
 	require.NoError(t, err)
 
-	require.Equal(t, expectedFoundEndpoint, actualEndpoint, "Test for unregistered endpoint found result not as expected")
+	requireEndpoint(t, actualEndpoint, models.Halt)
 
 	// Register the service endpoint
 	err = client.Register()
@@ -182,7 +322,34 @@ func TestGetServiceEndpoint(t *testing.T) {
 	actualEndpoint, err = client.GetServiceEndpoint(client.serviceKey)
 	require.NoError(t, err)
 
-	require.Equal(t, expectedFoundEndpoint, actualEndpoint, "Test for endpoint found result not as expected")
+	requireEndpoint(t, actualEndpoint, models.Unknown)
+}
+
+func TestGetServiceHealthDetails(t *testing.T) {
+	if mockKeeper == nil {
+		t.Skip("requires the mock Keeper to change the service status")
+	}
+
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+
+	_, err := client.GetServiceHealthDetails(client.serviceKey)
+	require.ErrorIs(t, err, types.ErrServiceNotFound)
+
+	// Try to clean-up after test
+	defer func() {
+		_ = client.Unregister()
+	}()
+
+	require.NoError(t, client.Register())
+	require.True(t, mockKeeper.SetStatus(client.serviceKey, models.Down))
+
+	results, err := client.GetServiceHealthDetails(client.serviceKey)
+	require.NoError(t, err)
+	require.Equal(t, []types.HealthCheckResult{{
+		Name:     "http " + common.ApiPingRoute,
+		Status:   models.Down,
+		Interval: "1s",
+	}}, results)
 }
 
 func TestIsServiceAvailableNotRegistered(t *testing.T) {
@@ -197,6 +364,18 @@ func TestIsServiceAvailableNotRegistered(t *testing.T) {
 	require.False(t, actual)
 	require.Error(t, err, "expected error")
 	require.Contains(t, err.Error(), "service has been unregistered", "Wrong error")
+	require.ErrorIs(t, err, types.ErrNotRegistered)
+	require.Equal(t, types.AvailabilityNotRegistered, types.AvailabilityOf(actual, err))
+}
+
+func TestIsServiceAvailableNeverRegistered(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+
+	actual, err := client.IsServiceAvailable(client.serviceKey)
+
+	require.False(t, actual)
+	require.ErrorIs(t, err, types.ErrNotRegistered)
+	require.Equal(t, types.AvailabilityNotRegistered, types.AvailabilityOf(actual, err))
 }
 
 func TestIsServiceAvailableNotHealthy(t *testing.T) {
@@ -215,13 +394,46 @@ func TestIsServiceAvailableNotHealthy(t *testing.T) {
 	err := client.Register()
 	require.NoError(t, err)
 
-	// Give time for health check to run
-	time.Sleep(2 * time.Second)
-
-	actual, err := client.IsServiceAvailable(client.serviceKey)
+	// Wait for the health check to run
+	var actual bool
+	require.Eventually(t, func() bool {
+		actual, err = client.IsServiceAvailable(client.serviceKey)
+		return errors.Is(err, types.ErrServiceNotHealthy)
+	}, watchTimeout, 10*time.Millisecond)
 	require.False(t, actual)
 	require.Error(t, err, "expected error")
 	require.Contains(t, err.Error(), "service not healthy", "Wrong error")
+	require.ErrorIs(t, err, types.ErrServiceNotHealthy)
+}
+
+func TestIsServiceAvailableGracePeriod(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.CheckGracePeriod = "30s"
+
+	// Try to clean-up after test
+	defer func() {
+		_ = client.Unregister()
+	}()
+
+	// Register the service endpoint, without test service to respond to health check
+	err := client.Register()
+	require.NoError(t, err)
+
+	resp, edgexErr := client.registryClient.RegistrationByServiceId(context.Background(), client.serviceKey)
+	require.NoError(t, edgexErr)
+	fakeClock := clock.NewFakeClock(time.UnixMilli(resp.Registration.Created))
+	client.config.Clock = fakeClock
+
+	actual, err := client.IsServiceAvailable(client.serviceKey)
+	require.False(t, actual)
+	require.ErrorIs(t, err, types.ErrServiceStarting)
+	require.NotErrorIs(t, err, types.ErrServiceNotHealthy)
+
+	fakeClock.Advance(31 * time.Second)
+
+	actual, err = client.IsServiceAvailable(client.serviceKey)
+	require.False(t, actual)
+	require.ErrorIs(t, err, types.ErrServiceNotHealthy)
 }
 
 func TestIsServiceAvailableHealthy(t *testing.T) {
@@ -235,7 +447,10 @@ func TestIsServiceAvailableHealthy(t *testing.T) {
 				writer.Header().Set(common.ContentType, common.ContentTypeText)
 				_, _ = writer.Write([]byte("pong"))
 
-				doneChan <- true
+				select {
+				case doneChan <- true:
+				default:
+				}
 			}
 		}
 	}))
@@ -257,13 +472,12 @@ func TestIsServiceAvailableHealthy(t *testing.T) {
 	err := client.Register()
 	require.NoError(t, err)
 
-	// Give time for health check to run
-	go func() {
-		time.Sleep(10 * time.Second)
-		doneChan <- false
-	}()
-
-	receivedPing := <-doneChan
+	// Wait for the health check to run
+	receivedPing := false
+	select {
+	case receivedPing = <-doneChan:
+	case <-time.After(watchTimeout):
+	}
 	require.True(t, receivedPing, "Never received health check ping")
 
 	actual, err := client.IsServiceAvailable(client.serviceKey)
@@ -295,3 +509,1554 @@ func makeKeeperClient(t *testing.T, serviceName string, serviceHost string, serv
 func getUniqueServiceName() string {
 	return serviceName + strconv.Itoa(time.Now().Nanosecond())
 }
+
+func TestIsAliveWithTLS(t *testing.T) {
+	server := keepertest.NewMockKeeper().StartTLS()
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverUrl.Port())
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tests := []struct {
+		name      string
+		tlsConfig types.TLSConfig
+		expected  bool
+	}{
+		{"Valid - trusted CA", types.TLSConfig{CAPEM: string(caPEM)}, true},
+		{"Valid - skip verify", types.TLSConfig{InsecureSkipVerify: true}, true},
+		{"Invalid - untrusted certificate", types.TLSConfig{}, false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			client, err := NewKeeperClient(types.Config{
+				Protocol:     "https",
+				Host:         serverUrl.Hostname(),
+				Port:         serverPort,
+				ServiceKey:   getUniqueServiceName(),
+				AuthInjector: NewNullAuthenticationInjector(),
+				TLSConfig:    testCase.tlsConfig,
+			})
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, client.IsAlive())
+		})
+	}
+}
+
+func TestIsAliveWithUnixSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "keeper.sock")
+	server, err := keepertest.NewMockKeeper().StartUnix(socketPath)
+	require.NoError(t, err)
+	defer server.Close()
+
+	client, err := NewKeeperClient(types.Config{
+		Protocol:     types.UnixProtocol,
+		Host:         socketPath,
+		ServiceKey:   getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+	})
+	require.NoError(t, err)
+	require.True(t, client.IsAlive())
+
+	_, err = NewKeeperClient(types.Config{
+		Protocol:     types.UnixProtocol,
+		Host:         socketPath,
+		ServiceKey:   getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+		TLSConfig:    types.TLSConfig{InsecureSkipVerify: true},
+	})
+	require.Error(t, err)
+}
+
+func TestStatus(t *testing.T) {
+	mock := keepertest.NewMockKeeper()
+	server := mock.Start()
+	defer server.Close()
+	tlsServer := mock.StartTLS()
+	defer tlsServer.Close()
+	closedServer := mock.Start()
+	closedServer.Close()
+
+	tests := []struct {
+		name              string
+		serverUrl         string
+		errorCode         int
+		expectedReachable bool
+		expectedCode      int
+		expectedTLSError  bool
+	}{
+		{"Alive", server.URL, 0, true, http.StatusOK, false},
+		{"Error response", server.URL, http.StatusServiceUnavailable, true, http.StatusServiceUnavailable, false},
+		{"Unreachable", closedServer.URL, 0, false, 0, false},
+		{"Untrusted certificate", tlsServer.URL, 0, false, 0, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			mock.SetErrorResponse(testCase.errorCode)
+			defer mock.SetErrorResponse(0)
+
+			serverUrl, _ := url.Parse(testCase.serverUrl)
+			serverPort, _ := strconv.Atoi(serverUrl.Port())
+			client, err := NewKeeperClient(types.Config{
+				Protocol:     serverUrl.Scheme,
+				Host:         serverUrl.Hostname(),
+				Port:         serverPort,
+				ServiceKey:   getUniqueServiceName(),
+				AuthInjector: NewNullAuthenticationInjector(),
+			})
+			require.NoError(t, err)
+
+			status, err := client.Status()
+			alive := testCase.expectedCode == http.StatusOK
+			if alive {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+			require.Equal(t, alive, status.Alive)
+			require.Equal(t, alive, client.IsAlive())
+			require.Equal(t, testCase.expectedReachable, status.Reachable)
+			require.Equal(t, testCase.expectedCode, status.StatusCode)
+			require.Equal(t, testCase.expectedTLSError, status.TLSError != nil)
+			require.Positive(t, status.Latency)
+		})
+	}
+}
+
+func TestStatusLatency(t *testing.T) {
+	requests := 0
+	handler := keepertest.NewMockKeeper().Handler()
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		if requests == 1 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		handler.ServeHTTP(writer, request)
+	}))
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverUrl.Port())
+	retryInterval := 200 * time.Millisecond
+	client, err := NewKeeperClient(types.Config{
+		Host:         serverUrl.Hostname(),
+		Port:         serverPort,
+		ServiceKey:   getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+		RetryPolicy:  retry.Policy{MaxAttempts: 2, InitialInterval: retryInterval},
+	})
+	require.NoError(t, err)
+
+	// Only the successful attempt is timed, not the wait before retrying
+	status, err := client.Status()
+	require.NoError(t, err)
+	require.Equal(t, 2, requests)
+	require.Positive(t, status.Latency)
+	require.Less(t, status.Latency, retryInterval)
+}
+
+func TestMeasureLatency(t *testing.T) {
+	mock := keepertest.NewMockKeeper()
+	server := mock.Start()
+	defer server.Close()
+	closedServer := mock.Start()
+	closedServer.Close()
+
+	tests := []struct {
+		name            string
+		serverUrl       string
+		samples         int
+		expectedSamples int
+		expectError     bool
+	}{
+		{"Reachable", server.URL, 5, 5, false},
+		{"Unreachable", closedServer.URL, 2, 0, true},
+		{"Invalid samples", server.URL, 0, 0, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			serverUrl, _ := url.Parse(testCase.serverUrl)
+			serverPort, _ := strconv.Atoi(serverUrl.Port())
+			client, err := NewKeeperClient(types.Config{
+				Host:         serverUrl.Hostname(),
+				Port:         serverPort,
+				ServiceKey:   getUniqueServiceName(),
+				AuthInjector: NewNullAuthenticationInjector(),
+			})
+			require.NoError(t, err)
+
+			report, err := client.MeasureLatency(testCase.samples)
+			if testCase.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, testCase.expectedSamples, report.Samples)
+			require.Equal(t, max(testCase.samples, 0)-testCase.expectedSamples, report.Failed)
+			if testCase.expectedSamples > 0 {
+				require.Positive(t, report.Min)
+				require.LessOrEqual(t, report.Min, report.Avg)
+				require.LessOrEqual(t, report.P95, report.Max)
+			}
+		})
+	}
+}
+
+func TestClientStats(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	mock := keepertest.NewMockKeeper()
+	server := mock.Start()
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverUrl.Port())
+	client, err := NewKeeperClient(types.Config{
+		Host:         serverUrl.Hostname(),
+		Port:         serverPort,
+		ServiceKey:   getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+		Clock:        fakeClock,
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.ClientStats{}, client.ClientStats())
+
+	require.True(t, client.IsAlive())
+	lastSuccess := fakeClock.Now()
+	require.Equal(t, types.ClientStats{LastSuccess: lastSuccess}, client.ClientStats())
+
+	// Keeper failing internally counts as a failure, unlike a request error such as not found
+	fakeClock.Advance(time.Second)
+	mock.SetErrorResponse(http.StatusInternalServerError)
+	require.False(t, client.IsAlive())
+	require.False(t, client.IsAlive())
+	mock.SetErrorResponse(0)
+	require.Equal(t, types.ClientStats{LastSuccess: lastSuccess, LastFailure: fakeClock.Now(), ConsecutiveFailures: 2}, client.ClientStats())
+
+	fakeClock.Advance(time.Second)
+	_, err = client.GetServiceEndpoint("unknown")
+	require.ErrorIs(t, err, types.ErrServiceNotFound)
+	stats := client.ClientStats()
+	require.Equal(t, fakeClock.Now(), stats.LastSuccess)
+	require.Zero(t, stats.ConsecutiveFailures)
+
+	// Being unauthorized counts as a failure, as no call succeeds until the access token is fixed
+	fakeClock.Advance(time.Second)
+	mock.SetErrorResponse(http.StatusUnauthorized)
+	require.False(t, client.IsAlive())
+	mock.SetErrorResponse(http.StatusForbidden)
+	require.False(t, client.IsAlive())
+	mock.SetErrorResponse(0)
+	stats = client.ClientStats()
+	require.Equal(t, fakeClock.Now(), stats.LastFailure)
+	require.Equal(t, 2, stats.ConsecutiveFailures)
+}
+
+func TestNewKeeperClientInvalidTLS(t *testing.T) {
+	_, err := NewKeeperClient(types.Config{
+		Host:      testRegistryHost,
+		Port:      testRegistryPort,
+		TLSConfig: types.TLSConfig{CAPEM: "not a certificate"},
+	})
+	require.Error(t, err)
+}
+
+func TestGetAllServiceEndpointsPartialResult(t *testing.T) {
+	if mockKeeper == nil {
+		t.Skip("requires the mock Keeper to inject incomplete registrations")
+	}
+
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+
+	// Try to clean-up after test
+	defer func() {
+		_ = client.Unregister()
+	}()
+
+	err := client.Register()
+	require.NoError(t, err)
+
+	endpoints, err := client.GetAllServiceEndpoints()
+	require.NoError(t, err)
+	expectedCount := len(endpoints)
+
+	incompleteServiceId := getUniqueServiceName()
+	mockKeeper.SetRegistration(dtos.Registration{ServiceId: incompleteServiceId})
+	defer func() {
+		mockKeeper.RemoveRegistration(incompleteServiceId)
+	}()
+
+	endpoints, err = client.GetAllServiceEndpoints()
+	require.Error(t, err)
+
+	var partialErr *types.PartialResultError
+	require.ErrorAs(t, err, &partialErr)
+	require.Equal(t, []string{incompleteServiceId}, partialErr.Incomplete)
+	require.Len(t, endpoints, expectedCount, "complete endpoints should still be returned")
+}
+
+func TestGetAllServiceEndpointsPaged(t *testing.T) {
+	mock := keepertest.NewMockKeeper()
+	server := mock.Start()
+	defer server.Close()
+
+	for _, serviceId := range []string{"core-command", "core-data", "core-metadata", "support-notifications", "support-scheduler"} {
+		mock.SetRegistration(dtos.Registration{ServiceId: serviceId, Host: serviceId, Port: defaultServicePort, Status: models.Up})
+	}
+
+	serverUrl, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverUrl.Port())
+	client, err := NewKeeperClient(types.Config{
+		Host:         serverUrl.Hostname(),
+		Port:         serverPort,
+		ServiceKey:   getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		offset      int
+		limit       int
+		expected    []string
+		expectError bool
+	}{
+		{"First page", 0, 2, []string{"core-command", "core-data"}, false},
+		{"Last page", 4, 2, []string{"support-scheduler"}, false},
+		{"Past the end", 5, 2, []string{}, false},
+		{"No limit", 3, -1, []string{"support-notifications", "support-scheduler"}, false},
+		{"Negative offset", -1, 2, nil, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			endpoints, total, err := client.GetAllServiceEndpointsPaged(testCase.offset, testCase.limit)
+			if testCase.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			serviceIds := make([]string, 0, len(endpoints))
+			for _, endpoint := range endpoints {
+				serviceIds = append(serviceIds, endpoint.ServiceId)
+			}
+			require.Equal(t, testCase.expected, serviceIds)
+			require.Equal(t, 5, total)
+		})
+	}
+}
+
+func TestGetServiceEndpointsByStatus(t *testing.T) {
+	mock := keepertest.NewMockKeeper()
+	server := mock.Start()
+	defer server.Close()
+
+	statuses := map[string]string{
+		"core-command":   models.Up,
+		"core-data":      models.Down,
+		"core-metadata":  models.Up,
+		"device-virtual": models.Halt,
+	}
+	for serviceId, status := range statuses {
+		mock.SetRegistration(dtos.Registration{ServiceId: serviceId, Host: serviceId, Port: defaultServicePort, Status: status})
+	}
+
+	serverUrl, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverUrl.Port())
+	client, err := NewKeeperClient(types.Config{
+		Host:         serverUrl.Hostname(),
+		Port:         serverPort,
+		ServiceKey:   getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		status   string
+		expected []string
+	}{
+		{"Up", models.Up, []string{"core-command", "core-metadata"}},
+		{"Down lower case", "down", []string{"core-data"}},
+		{"Halt", models.Halt, []string{"device-virtual"}},
+		{"None", models.Unknown, []string{}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			endpoints, err := client.GetServiceEndpointsByStatus(testCase.status)
+			require.NoError(t, err)
+
+			serviceIds := make([]string, 0, len(endpoints))
+			for _, endpoint := range endpoints {
+				serviceIds = append(serviceIds, endpoint.ServiceId)
+			}
+			require.Equal(t, testCase.expected, serviceIds)
+		})
+	}
+}
+
+func TestFindServiceEndpoints(t *testing.T) {
+	mock := keepertest.NewMockKeeper()
+	server := mock.Start()
+	defer server.Close()
+
+	for _, serviceId := range []string{"app-rules-engine", "core-data", "device-modbus", "device-virtual"} {
+		mock.SetRegistration(dtos.Registration{ServiceId: serviceId, Host: serviceId, Port: defaultServicePort, Status: models.Up})
+	}
+	mock.SetRegistration(dtos.Registration{ServiceId: "support-scheduler", Status: models.Up})
+
+	serverUrl, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverUrl.Port())
+	client, err := NewKeeperClient(types.Config{
+		Host:         serverUrl.Hostname(),
+		Port:         serverPort,
+		ServiceKey:   getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+	})
+	require.NoError(t, err)
+
+	// The incomplete registration is only reported when it matches
+	tests := []struct {
+		name        string
+		pattern     string
+		expected    []string
+		expectError bool
+	}{
+		{"Prefix", "device-*", []string{"device-modbus", "device-virtual"}, false},
+		{"Single character", "core-dat?", []string{"core-data"}, false},
+		{"Character class", "[ac]*", []string{"app-rules-engine", "core-data"}, false},
+		{"Exact", "core-data", []string{"core-data"}, false},
+		{"None", "security-*", []string{}, false},
+		{"Invalid", "device-[", nil, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			endpoints, err := client.FindServiceEndpoints(testCase.pattern)
+			if testCase.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			serviceIds := make([]string, 0, len(endpoints))
+			for _, endpoint := range endpoints {
+				serviceIds = append(serviceIds, endpoint.ServiceId)
+			}
+			require.Equal(t, testCase.expected, serviceIds)
+		})
+	}
+
+	endpoints, err := client.FindServiceEndpoints("support-*")
+	var partialErr *types.PartialResultError
+	require.ErrorAs(t, err, &partialErr)
+	require.Equal(t, []string{"support-scheduler"}, partialErr.Incomplete)
+	require.Empty(t, endpoints)
+}
+
+func TestExportRegistrations(t *testing.T) {
+	source := keepertest.NewMockKeeper()
+	sourceServer := source.Start()
+	defer sourceServer.Close()
+	target := keepertest.NewMockKeeper()
+	targetServer := target.Start()
+	defer targetServer.Close()
+
+	for _, serviceId := range []string{"core-data~replica-1", "core-command"} {
+		host, _ := types.SplitInstanceKey(serviceId)
+		source.SetRegistration(dtos.Registration{
+			ServiceId:   serviceId,
+			Host:        host,
+			Port:        defaultServicePort,
+			Status:      models.Up,
+			HealthCheck: dtos.HealthCheck{Interval: "10s", Path: common.ApiPingRoute, Type: "http"},
+		})
+	}
+	source.SetRegistration(dtos.Registration{ServiceId: "device-virtual", Host: "device-virtual", Port: defaultServicePort, Status: models.Halt})
+
+	newClient := func(server *httptest.Server) *keeperClient {
+		serverUrl, _ := url.Parse(server.URL)
+		serverPort, _ := strconv.Atoi(serverUrl.Port())
+		client, err := NewKeeperClient(types.Config{
+			Host:         serverUrl.Hostname(),
+			Port:         serverPort,
+			ServiceKey:   getUniqueServiceName(),
+			AuthInjector: NewNullAuthenticationInjector(),
+		})
+		require.NoError(t, err)
+		return client
+	}
+
+	registrations, err := newClient(sourceServer).ExportRegistrations()
+	require.NoError(t, err)
+	require.Equal(t, []types.ServiceRegistration{
+		{ServiceKey: "core-command", Host: "core-command", Port: defaultServicePort, CheckRoute: common.ApiPingRoute, CheckInterval: "10s", CheckType: "http"},
+		{ServiceKey: "core-data", InstanceId: "replica-1", Host: "core-data", Port: defaultServicePort, CheckRoute: common.ApiPingRoute, CheckInterval: "10s", CheckType: "http"},
+	}, registrations)
+
+	// The snapshot seeds another registry once serialized
+	snapshot, err := json.Marshal(registrations)
+	require.NoError(t, err)
+	var imported []types.ServiceRegistration
+	require.NoError(t, json.Unmarshal(snapshot, &imported))
+	require.NoError(t, newClient(targetServer).RegisterAll(imported))
+
+	registration, ok := target.Registration("core-data~replica-1")
+	require.True(t, ok)
+	require.Equal(t, dtos.HealthCheck{Interval: "10s", Path: common.ApiPingRoute, Type: "http"}, registration.HealthCheck)
+	require.Len(t, target.Registrations(), 2)
+
+	source.SetErrorResponse(http.StatusServiceUnavailable)
+	_, err = newClient(sourceServer).ExportRegistrations()
+	require.Error(t, err)
+}
+
+func TestListServiceEndpoints(t *testing.T) {
+	mock := keepertest.NewMockKeeper()
+	server := mock.Start()
+	defer server.Close()
+
+	for _, serviceId := range []string{"core-data", "core-command", "core-metadata"} {
+		mock.SetRegistration(dtos.Registration{ServiceId: serviceId, Host: serviceId, Port: defaultServicePort, Status: models.Up})
+	}
+	mock.SetStatus("core-command", models.Down)
+
+	serverUrl, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverUrl.Port())
+	client, err := NewKeeperClient(types.Config{
+		Host:          serverUrl.Hostname(),
+		Port:          serverPort,
+		ServiceKey:    getUniqueServiceName(),
+		AuthInjector:  NewNullAuthenticationInjector(),
+		EndpointOrder: types.EndpointOrderHealth,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		options     types.ListOptions
+		expected    []string
+		expectError bool
+	}{
+		{"Configured order", types.ListOptions{}, []string{"core-data", "core-metadata", "core-command"}, false},
+		{"Service ID", types.ListOptions{Order: types.EndpointOrderServiceId}, []string{"core-command", "core-data", "core-metadata"}, false},
+		{"Service ID descending", types.ListOptions{Order: types.EndpointOrderServiceId, Descending: true}, []string{"core-metadata", "core-data", "core-command"}, false},
+		{"Last updated", types.ListOptions{Order: types.EndpointOrderLastUpdated}, []string{"core-command"}, false},
+		{"Filtered", types.ListOptions{Order: types.EndpointOrderServiceId, ServiceIdFilter: regexp.MustCompile(`^core-(data|metadata)$`)},
+			[]string{"core-data", "core-metadata"}, false},
+		{"Unknown order", types.ListOptions{Order: "bogus"}, nil, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			endpoints, err := client.ListServiceEndpoints(testCase.options)
+			if testCase.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			serviceIds := make([]string, 0, len(endpoints))
+			for _, endpoint := range endpoints {
+				serviceIds = append(serviceIds, endpoint.ServiceId)
+			}
+			// Only the most recently updated endpoint is known for sure when ordering by last update
+			if testCase.options.Order == types.EndpointOrderLastUpdated {
+				serviceIds = serviceIds[:len(testCase.expected)]
+			}
+			require.Equal(t, testCase.expected, serviceIds)
+		})
+	}
+}
+
+type countingRoundTripper struct {
+	count int
+}
+
+func (c *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.count++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestCustomTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		setTransport func(config *types.Config, transport http.RoundTripper)
+	}{
+		{"Transport", func(config *types.Config, transport http.RoundTripper) { config.Transport = transport }},
+		{"HttpClient", func(config *types.Config, transport http.RoundTripper) {
+			config.HttpClient = &http.Client{Transport: transport}
+		}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			transport := &countingRoundTripper{}
+			registryConfig := types.Config{
+				Host:         testRegistryHost,
+				Port:         testRegistryPort,
+				ServiceKey:   getUniqueServiceName(),
+				AuthInjector: NewNullAuthenticationInjector(),
+			}
+			testCase.setTransport(&registryConfig, transport)
+
+			client, err := NewKeeperClient(registryConfig)
+			require.NoError(t, err)
+
+			require.True(t, client.IsAlive())
+			require.Equal(t, 1, transport.count, "request not sent through the custom transport")
+		})
+	}
+}
+
+func TestRateLimitCancelled(t *testing.T) {
+	client, err := NewKeeperClient(types.Config{
+		Host:         testRegistryHost,
+		Port:         testRegistryPort,
+		ServiceKey:   getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+		RateLimit:    types.RateLimitConfig{ReadsPerSecond: 0.001},
+	})
+	require.NoError(t, err)
+
+	// The first read takes the only token, so the second one waits until its context is cancelled
+	_, edgexErr := client.registryClient.AllRegistry(context.Background(), false)
+	require.NoError(t, edgexErr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, edgexErr = client.registryClient.AllRegistry(ctx, false)
+	require.Error(t, edgexErr)
+	require.Equal(t, http.StatusBadRequest, edgexErr.Code())
+	require.ErrorIs(t, edgexErr, context.Canceled)
+}
+
+func TestConnectionReuse(t *testing.T) {
+	var lock sync.Mutex
+	connections := 0
+	server := httptest.NewUnstartedServer(keepertest.NewMockKeeper().Handler())
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			lock.Lock()
+			connections++
+			lock.Unlock()
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverUrl.Port())
+	client, err := NewKeeperClient(types.Config{
+		Host:         serverUrl.Hostname(),
+		Port:         serverPort,
+		ServiceKey:   getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		require.True(t, client.IsAlive())
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Equal(t, 1, connections, "the connection should have been reused")
+}
+
+func TestRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		select {
+		case <-request.Context().Done():
+		case <-time.After(watchTimeout):
+		}
+	}))
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverUrl.Port())
+	client, err := NewKeeperClient(types.Config{
+		Host:          serverUrl.Hostname(),
+		Port:          serverPort,
+		ServiceKey:    getUniqueServiceName(),
+		AuthInjector:  NewNullAuthenticationInjector(),
+		HttpTransport: types.HttpTransportConfig{RequestTimeout: "50ms"},
+	})
+	require.NoError(t, err)
+
+	start := time.Now()
+	require.False(t, client.IsAlive())
+	require.Less(t, time.Since(start), watchTimeout)
+
+	_, err = NewKeeperClient(types.Config{HttpTransport: types.HttpTransportConfig{RequestTimeout: "bogus"}})
+	require.Error(t, err)
+}
+
+// testLogger captures the warnings logged, discarding everything else
+type testLogger struct {
+	logger.LoggingClient
+	warnings []string
+}
+
+func (l *testLogger) Warn(msg string, _ ...interface{}) {
+	l.warnings = append(l.warnings, msg)
+}
+
+func (l *testLogger) Warnf(msg string, args ...interface{}) {
+	l.warnings = append(l.warnings, fmt.Sprintf(msg, args...))
+}
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name             string
+		failures         int
+		failureStatus    int
+		expectedRequests int
+		expectError      bool
+	}{
+		{"Valid - recovers after retries", 2, http.StatusServiceUnavailable, 3, false},
+		{"Invalid - attempts exhausted", 5, http.StatusInternalServerError, 3, true},
+		{"Invalid - client error not retried", 5, http.StatusBadRequest, 1, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			requests := 0
+			handler := keepertest.NewMockKeeper().Handler()
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				requests++
+				if requests <= testCase.failures {
+					writer.WriteHeader(testCase.failureStatus)
+					return
+				}
+				handler.ServeHTTP(writer, request)
+			}))
+			defer server.Close()
+
+			serverUrl, _ := url.Parse(server.URL)
+			serverPort, _ := strconv.Atoi(serverUrl.Port())
+
+			lc := &testLogger{LoggingClient: logger.NewMockClient()}
+			client, err := NewKeeperClient(types.Config{
+				Host:         serverUrl.Hostname(),
+				Port:         serverPort,
+				ServiceKey:   getUniqueServiceName(),
+				AuthInjector: NewNullAuthenticationInjector(),
+				RetryPolicy:  retry.Policy{MaxAttempts: 3, InitialInterval: time.Millisecond},
+				Logger:       lc,
+			})
+			require.NoError(t, err)
+
+			_, err = client.GetAllServiceEndpoints()
+			require.Equal(t, testCase.expectedRequests, requests)
+			require.Len(t, lc.warnings, testCase.expectedRequests-1, "each retry should be logged")
+			if testCase.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReconfigure(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+
+	// Try to clean-up after test
+	defer func() {
+		_ = client.Unregister()
+	}()
+
+	// Not registered yet, so the new settings are only applied locally
+	newConfig := *client.config
+	newConfig.ServicePort = defaultServicePort + 1
+	err := client.Reconfigure(newConfig)
+	require.NoError(t, err)
+	resp, err := client.registryClient.RegistrationByServiceId(context.Background(), client.serviceKey)
+	require.False(t, err == nil && resp.StatusCode == http.StatusOK, "service should not have been registered by Reconfigure")
+
+	err = client.Register()
+	require.NoError(t, err)
+
+	// Registration details changed, so the registration is updated
+	newConfig.ServicePort = defaultServicePort + 2
+	err = client.Reconfigure(newConfig)
+	require.NoError(t, err)
+	endpoint, err := client.GetServiceEndpoint(client.serviceKey)
+	require.NoError(t, err)
+	require.Equal(t, defaultServicePort+2, endpoint.Port)
+
+	// Service key changed, so the previous registration is de-registered and the new one registered
+	previousServiceKey := client.serviceKey
+	newConfig.ServiceKey = getUniqueServiceName()
+	err = client.Reconfigure(newConfig)
+	require.NoError(t, err)
+	previous, err := client.registryClient.RegistrationByServiceId(context.Background(), previousServiceKey)
+	require.NoError(t, err)
+	require.Equal(t, models.Halt, previous.Registration.Status)
+	_, err = client.GetServiceEndpoint(newConfig.ServiceKey)
+	require.NoError(t, err)
+
+	// Invalid configuration is rejected and the current settings kept
+	invalidConfig := newConfig
+	invalidConfig.EndpointOrder = "bogus"
+	err = client.Reconfigure(invalidConfig)
+	require.Error(t, err)
+	require.Equal(t, newConfig.ServiceKey, client.serviceKey)
+}
+
+func TestRegisterSeparateCheckPortError(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.CheckPort = defaultServicePort + 1
+
+	err := client.Register()
+	require.ErrorIs(t, err, types.ErrUnsupported, "Expected error due to unsupported health check port")
+}
+
+func TestRegisterSeparateCheckHostError(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.CheckHost = "core-data.edgex.svc"
+
+	err := client.Register()
+	require.ErrorIs(t, err, types.ErrUnsupported, "Expected error due to unsupported health check host")
+
+	client.config.CheckHost = defaultServiceHost
+	defer func() {
+		_ = client.Unregister()
+	}()
+	require.NoError(t, client.Register())
+}
+
+func TestRegisterCheckType(t *testing.T) {
+	tests := []struct {
+		name        string
+		checkType   string
+		expected    string
+		expectError bool
+	}{
+		{"Default", "", "http", false},
+		{"HTTPS", "https", "https", false},
+		{"Unsupported", "tcp", "", true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+			client.healthCheckType = types.Config{CheckType: testCase.checkType}.GetCheckType()
+
+			// Try to clean-up after test
+			defer func() {
+				_ = client.Unregister()
+			}()
+
+			err := client.Register()
+			if testCase.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			resp, edgexErr := client.registryClient.RegistrationByServiceId(context.Background(), client.serviceKey)
+			require.NoError(t, edgexErr)
+			require.Equal(t, testCase.expected, resp.Registration.HealthCheck.Type)
+		})
+	}
+}
+
+func TestNamespace(t *testing.T) {
+	serviceKey := getUniqueServiceName()
+	site1Client := makeKeeperClient(t, serviceKey, defaultServiceHost, defaultServicePort, true)
+	site1Client.config.Namespace = "site1"
+	site2Client := makeKeeperClient(t, serviceKey, defaultServiceHost, defaultServicePort+1, true)
+	site2Client.config.Namespace = "site2"
+
+	// Try to clean-up after test
+	defer func() {
+		_ = site1Client.UnregisterByServiceId(serviceKey)
+		_ = site2Client.UnregisterByServiceId(serviceKey)
+	}()
+
+	require.NoError(t, site1Client.Register())
+	require.NoError(t, site2Client.Register())
+
+	tests := []struct {
+		name         string
+		client       *keeperClient
+		expectedPort int
+	}{
+		{"Site 1", site1Client, defaultServicePort},
+		{"Site 2", site2Client, defaultServicePort + 1},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			endpoint, err := testCase.client.GetServiceEndpoint(serviceKey)
+			require.NoError(t, err)
+			require.Equal(t, serviceKey, endpoint.ServiceId)
+			require.Equal(t, testCase.expectedPort, endpoint.Port)
+
+			endpoints, err := testCase.client.GetAllServiceEndpoints()
+			require.NoError(t, err)
+			require.Equal(t, []types.ServiceEndpoint{endpoint}, endpoints)
+		})
+	}
+
+	if mockKeeper != nil {
+		_, ok := mockKeeper.Registration("site1." + serviceKey)
+		require.True(t, ok)
+	}
+
+	// The registrations of the other namespace are left alone
+	require.NoError(t, site1Client.UnregisterByServiceId(serviceKey))
+	_, err := site2Client.GetServiceEndpoint(serviceKey)
+	require.NoError(t, err)
+
+	client := makeKeeperClient(t, serviceKey, defaultServiceHost, defaultServicePort, true)
+	client.config.Namespace = "site.1"
+	_, err = NewKeeperClient(*client.config)
+	require.Error(t, err)
+}
+
+func TestInstanceId(t *testing.T) {
+	serviceKey := getUniqueServiceName()
+	replica1Client := makeKeeperClient(t, serviceKey, defaultServiceHost, defaultServicePort, true)
+	replica1Client.config.InstanceId = "replica-1"
+	replica1Client, err := NewKeeperClient(*replica1Client.config)
+	require.NoError(t, err)
+	replica2Client := makeKeeperClient(t, serviceKey, defaultServiceHost, defaultServicePort+1, true)
+	replica2Client.config.InstanceId = "replica-2"
+	replica2Client, err = NewKeeperClient(*replica2Client.config)
+	require.NoError(t, err)
+
+	// Try to clean-up after test
+	defer func() {
+		_ = replica1Client.UnregisterByServiceId(serviceKey + "~replica-1")
+		_ = replica2Client.UnregisterByServiceId(serviceKey + "~replica-2")
+	}()
+
+	// The replicas don't overwrite each other's registration
+	require.NoError(t, replica1Client.Register())
+	require.NoError(t, replica2Client.Register())
+
+	endpoints, err := replica1Client.GetAllServiceEndpoints()
+	require.NoError(t, err)
+	var instances []types.ServiceEndpoint
+	for _, endpoint := range endpoints {
+		if endpoint.ServiceId == serviceKey {
+			instances = append(instances, endpoint)
+		}
+	}
+	require.Len(t, instances, 2)
+	require.Equal(t, "replica-1", instances[0].InstanceId)
+	require.Equal(t, defaultServicePort, instances[0].Port)
+	require.Equal(t, "replica-2", instances[1].InstanceId)
+	require.Equal(t, defaultServicePort+1, instances[1].Port)
+
+	endpoint, err := replica1Client.GetServiceEndpoint(serviceKey + "~replica-2")
+	require.NoError(t, err)
+	require.Equal(t, instances[1], endpoint)
+
+	// The service key finds a replica, preferring the healthy ones
+	if mockKeeper != nil {
+		require.True(t, mockKeeper.SetStatus(serviceKey+"~replica-2", models.Up))
+		endpoint, err = replica1Client.GetServiceEndpoint(serviceKey)
+		require.NoError(t, err)
+		require.Equal(t, "replica-2", endpoint.InstanceId)
+		require.Equal(t, defaultServicePort+1, endpoint.Port)
+
+		available, err := replica1Client.IsServiceAvailable(serviceKey)
+		require.NoError(t, err)
+		require.True(t, available)
+	}
+	endpoint, err = replica1Client.GetServiceEndpoint(serviceKey)
+	require.NoError(t, err)
+	require.Equal(t, serviceKey, endpoint.ServiceId)
+	details, err := replica1Client.GetServiceHealthDetails(serviceKey)
+	require.NoError(t, err)
+	require.Len(t, details, 1)
+
+	replica1Client.config.InstanceId = "replica/1"
+	_, err = NewKeeperClient(*replica1Client.config)
+	require.Error(t, err)
+}
+
+func TestRegisterCheck(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+
+	// Try to clean-up after test
+	defer func() {
+		_ = client.Unregister()
+	}()
+
+	require.NoError(t, client.Register())
+
+	healthCheck := func() dtos.HealthCheck {
+		resp, edgexErr := client.registryClient.RegistrationByServiceId(context.Background(), client.serviceKey)
+		require.NoError(t, edgexErr)
+		return resp.Registration.HealthCheck
+	}
+
+	checkUrl := fmt.Sprintf("http://%s:%d/api/v3/health", defaultServiceHost, defaultServicePort)
+	require.NoError(t, client.RegisterCheck("health", "Health", "", checkUrl, "5s"))
+	require.Equal(t, dtos.HealthCheck{Interval: "5s", Path: "/api/v3/health", Type: "http"}, healthCheck())
+
+	tests := []struct {
+		name     string
+		id       string
+		checkUrl string
+	}{
+		{"Other host", "health", fmt.Sprintf("http://core-data:%d/api/v3/health", defaultServicePort)},
+		{"Other port", "health", fmt.Sprintf("http://%s:9000/api/v3/health", defaultServiceHost)},
+		{"Unsupported type", "health", fmt.Sprintf("tcp://%s:%d", defaultServiceHost, defaultServicePort)},
+		{"Second check", "db", checkUrl},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := client.RegisterCheck(testCase.id, "", "", testCase.checkUrl, "5s")
+			require.ErrorIs(t, err, types.ErrUnsupported)
+			require.Equal(t, "/api/v3/health", healthCheck().Path)
+		})
+	}
+
+	// Unknown checks are ignored, while the registered one is replaced by the configured check again
+	require.NoError(t, client.UnregisterCheck("db"))
+	require.Equal(t, "/api/v3/health", healthCheck().Path)
+	require.NoError(t, client.UnregisterCheck("health"))
+	require.Equal(t, dtos.HealthCheck{Interval: "1s", Path: common.ApiPingRoute, Type: "http"}, healthCheck())
+}
+
+func TestRegisterDryRun(t *testing.T) {
+	unauthorizedServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusForbidden)
+	}))
+	defer unauthorizedServer.Close()
+	unauthorizedUrl, _ := url.Parse(unauthorizedServer.URL)
+	unauthorizedPort, _ := strconv.Atoi(unauthorizedUrl.Port())
+
+	tests := []struct {
+		name          string
+		modify        func(config *types.Config)
+		expectedError error
+	}{
+		{"Valid", func(config *types.Config) {}, nil},
+		{"Invalid registration", func(config *types.Config) { config.ServicePort = 70000 }, types.ErrInvalidRegistration},
+		{"Access denied", func(config *types.Config) {
+			config.Host = unauthorizedUrl.Hostname()
+			config.Port = unauthorizedPort
+		}, types.ErrAccessDenied},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := *makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true).config
+			testCase.modify(&config)
+			client, err := NewKeeperClient(config)
+			require.NoError(t, err)
+
+			err = client.Register(types.WithDryRun())
+			if testCase.expectedError != nil {
+				require.ErrorIs(t, err, testCase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			// Nothing is registered
+			require.False(t, client.registered.Load())
+			_, err = client.GetServiceEndpoint(client.serviceKey)
+			require.ErrorIs(t, err, types.ErrServiceNotFound)
+		})
+	}
+}
+
+func TestRegisterInvalidRegistration(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, 70000, true)
+
+	err := client.Register()
+	require.ErrorIs(t, err, types.ErrInvalidRegistration)
+	require.False(t, client.registered.Load())
+
+	_, err = client.GetServiceEndpoint(client.serviceKey)
+	require.ErrorIs(t, err, types.ErrServiceNotFound, "nothing should have been sent to Keeper")
+}
+
+func TestRegisterInvalidServiceKey(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.ServiceKeyPolicy = types.ServiceKeyPolicy{Enabled: true, Prefixes: []string{"core-"}}
+
+	err := client.Register()
+	require.ErrorIs(t, err, types.ErrInvalidServiceKey)
+	require.False(t, client.registered.Load())
+}
+
+func TestRegisterService(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), "", 0, false)
+	registration := types.ServiceRegistration{
+		ServiceKey:    getUniqueServiceName(),
+		Host:          "sidecar",
+		Port:          defaultServicePort,
+		CheckRoute:    common.ApiPingRoute,
+		CheckInterval: "1s",
+	}
+
+	// Try to clean-up after test
+	defer func() {
+		_ = client.UnregisterByServiceId(registration.ServiceKey)
+	}()
+
+	err := client.RegisterService(registration)
+	require.NoError(t, err)
+
+	endpoint, err := client.GetServiceEndpoint(registration.ServiceKey)
+	require.NoError(t, err)
+	require.Equal(t, "sidecar", endpoint.Host)
+	require.Equal(t, defaultServicePort, endpoint.Port)
+
+	// A replica is registered with its instance key
+	replica := registration
+	replica.InstanceId = "replica-1"
+	defer func() {
+		_ = client.UnregisterByServiceId(replica.GetInstanceKey())
+	}()
+	require.NoError(t, client.RegisterService(replica))
+	endpoint, err = client.GetServiceEndpoint(registration.ServiceKey + "~replica-1")
+	require.NoError(t, err)
+	require.Equal(t, "replica-1", endpoint.InstanceId)
+
+	replica.ServiceKey = "sidecar~replica-1"
+	replica.InstanceId = ""
+	err = client.RegisterService(replica)
+	require.Error(t, err)
+
+	registration.Host = ""
+	err = client.RegisterService(registration)
+	require.Error(t, err)
+}
+
+func TestRegisterAll(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, false)
+	client.config.ServiceKeyPolicy = types.ServiceKeyPolicy{Enabled: true, Prefixes: []string{"app-"}}
+
+	valid := []string{"app-" + getUniqueServiceName(), "app-" + getUniqueServiceName()}
+	invalidKey := "device-" + getUniqueServiceName()
+	missingInfo := "app-" + getUniqueServiceName()
+	registrations := []types.ServiceRegistration{
+		{ServiceKey: valid[0], Host: defaultServiceHost, Port: defaultServicePort, CheckRoute: common.ApiPingRoute, CheckInterval: "1s"},
+		{ServiceKey: invalidKey, Host: defaultServiceHost, Port: defaultServicePort, CheckRoute: common.ApiPingRoute, CheckInterval: "1s"},
+		{ServiceKey: valid[1], Host: defaultServiceHost, Port: defaultServicePort + 1, CheckRoute: common.ApiPingRoute, CheckInterval: "1s", CheckType: "https"},
+		{ServiceKey: missingInfo, Host: defaultServiceHost},
+	}
+
+	// Try to clean-up after test
+	defer func() {
+		for _, serviceKey := range valid {
+			_ = client.registryClient.Deregister(context.Background(), serviceKey)
+		}
+	}()
+
+	err := client.RegisterAll(registrations)
+	require.Error(t, err)
+	require.ErrorIs(t, err, types.ErrInvalidServiceKey)
+
+	var batchErr *types.BatchRegistrationError
+	require.ErrorAs(t, err, &batchErr)
+	require.Len(t, batchErr.Failed, 2)
+	require.Contains(t, batchErr.Failed, invalidKey)
+	require.Contains(t, batchErr.Failed, missingInfo)
+
+	for _, serviceKey := range valid {
+		_, edgexErr := client.registryClient.RegistrationByServiceId(context.Background(), serviceKey)
+		require.NoError(t, edgexErr)
+	}
+
+	// Registering services which already exist updates them
+	err = client.RegisterAll(registrations[:1])
+	require.NoError(t, err)
+	// Registering other services doesn't register the current service
+	require.False(t, client.registered.Load())
+}
+
+func TestGetServiceEndpointNotFound(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+
+	_, err := client.GetServiceEndpoint(client.serviceKey)
+	require.ErrorIs(t, err, types.ErrServiceNotFound)
+}
+
+func TestAccessDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverUrl.Port())
+
+	client, err := NewKeeperClient(types.Config{
+		Host:         serverUrl.Hostname(),
+		Port:         serverPort,
+		ServiceKey:   getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+	})
+	require.NoError(t, err)
+
+	_, err = client.GetServiceEndpoint(client.serviceKey)
+	require.ErrorIs(t, err, types.ErrAccessDenied)
+
+	_, err = client.IsServiceAvailable(client.serviceKey)
+	require.ErrorIs(t, err, types.ErrAccessDenied)
+}
+
+func TestAccessTokenRenewal(t *testing.T) {
+	if mockKeeper == nil {
+		t.Skip("requires the mock Keeper behind the authorizing server")
+	}
+
+	const renewedToken = "renewed-token"
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Header.Get("Authorization") != "Bearer "+renewedToken {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mockKeeper.Handler().ServeHTTP(writer, request)
+	}))
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverUrl.Port())
+
+	tests := []struct {
+		name        string
+		tokenErr    error
+		expectError bool
+	}{
+		{"Renewed", nil, false},
+		{"Renewal failed", fmt.Errorf("secret store unavailable"), true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			renewals := 0
+			client, err := NewKeeperClient(types.Config{
+				Host:         serverUrl.Hostname(),
+				Port:         serverPort,
+				ServiceKey:   getUniqueServiceName(),
+				AuthInjector: NewNullAuthenticationInjector(),
+				GetAccessToken: func() (string, error) {
+					renewals++
+					return renewedToken, testCase.tokenErr
+				},
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, !testCase.expectError, client.IsAlive())
+			require.Equal(t, 1, renewals)
+
+			// The renewed token is kept for the following requests
+			require.Equal(t, !testCase.expectError, client.IsAlive())
+			if testCase.expectError {
+				require.Equal(t, 2, renewals)
+				require.Zero(t, client.ClientStats().TokenRenewals)
+				return
+			}
+			require.Equal(t, 1, renewals)
+			require.Equal(t, 1, client.ClientStats().TokenRenewals)
+
+			// The renewed token is also kept when reconfiguring
+			require.NoError(t, client.Reconfigure(*client.config))
+			require.True(t, client.IsAlive())
+			require.Equal(t, 1, renewals)
+		})
+	}
+}
+
+type reportedCall struct {
+	backend   string
+	operation string
+	failed    bool
+}
+
+func TestAccessTokenRenewBeforeExpiry(t *testing.T) {
+	tests := []struct {
+		name     string
+		tokenTTL string
+		jwt      bool
+	}{
+		{"JWT exp claim", "", true},
+		{"Token TTL", "60s", false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			fakeClock := clock.NewFakeClock(time.Now())
+			mock := keepertest.NewMockKeeper()
+
+			var lock sync.Mutex
+			latestToken := ""
+			unauthorized := 0
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				lock.Lock()
+				authorized := latestToken != "" && request.Header.Get("Authorization") == "Bearer "+latestToken
+				if !authorized {
+					unauthorized++
+				}
+				lock.Unlock()
+
+				if !authorized {
+					writer.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				mock.Handler().ServeHTTP(writer, request)
+			}))
+			defer server.Close()
+
+			serverUrl, _ := url.Parse(server.URL)
+			serverPort, _ := strconv.Atoi(serverUrl.Port())
+
+			renewals := 0
+			client, err := NewKeeperClient(types.Config{
+				Host:                   serverUrl.Hostname(),
+				Port:                   serverPort,
+				ServiceKey:             getUniqueServiceName(),
+				AuthInjector:           NewNullAuthenticationInjector(),
+				AccessTokenRenewBefore: "30s",
+				AccessTokenTTL:         testCase.tokenTTL,
+				Clock:                  fakeClock,
+				GetAccessToken: func() (string, error) {
+					renewals++
+					token := fmt.Sprintf("token-%d", renewals)
+					if testCase.jwt {
+						claims := fmt.Sprintf(`{"exp":%d}`, fakeClock.Now().Add(time.Minute).Unix())
+						token = "header." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+					}
+
+					lock.Lock()
+					defer lock.Unlock()
+					latestToken = token
+					return token, nil
+				},
+			})
+			require.NoError(t, err)
+
+			// A token is obtained before the first request
+			require.True(t, client.IsAlive())
+			require.Equal(t, 1, renewals)
+
+			fakeClock.Advance(20 * time.Second)
+			require.True(t, client.IsAlive())
+			require.Equal(t, 1, renewals)
+
+			// Renewed once within 30 seconds of the expiry
+			fakeClock.Advance(20 * time.Second)
+			require.True(t, client.IsAlive())
+			require.Equal(t, 2, renewals)
+			require.Zero(t, unauthorized, "requests should not have been rejected")
+		})
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	encode := func(claims string) string {
+		return "header." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		expected time.Time
+	}{
+		{"JWT with exp", encode(`{"sub":"core-data","exp":1700000000}`), time.Unix(1700000000, 0)},
+		{"JWT without exp", encode(`{"sub":"core-data"}`), time.Time{}},
+		{"Opaque token", "s.abcdef", time.Time{}},
+		{"Invalid payload", "header.!!!.signature", time.Time{}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, tokenExpiry(testCase.token))
+		})
+	}
+}
+
+type testMetricsReporter struct {
+	calls []reportedCall
+}
+
+func (r *testMetricsReporter) ReportCall(backend string, operation string, _ time.Duration, err error) {
+	r.calls = append(r.calls, reportedCall{backend: backend, operation: operation, failed: err != nil})
+}
+
+func TestMetricsReporter(t *testing.T) {
+	reporter := &testMetricsReporter{}
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.MetricsReporter = reporter
+
+	require.True(t, client.IsAlive())
+	_, err := client.GetServiceEndpoint(client.serviceKey)
+	require.Error(t, err)
+
+	// The service key not being registered as is, it is also looked up among the replicas
+	expected := []reportedCall{
+		{backend: "keeper", operation: "Ping", failed: false},
+		{backend: "keeper", operation: "RegistrationByServiceId", failed: true},
+		{backend: "keeper", operation: "AllRegistry", failed: false},
+	}
+	require.Equal(t, expected, reporter.calls)
+}
+
+type testAuditSink struct {
+	records []types.AuditRecord
+}
+
+func (s *testAuditSink) RecordAudit(record types.AuditRecord) {
+	s.records = append(s.records, record)
+}
+
+func TestAuditSink(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	sink := &testAuditSink{}
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.AuditSink = sink
+	client.config.Clock = fakeClock
+
+	checkUrl := fmt.Sprintf("http://%s:%d/api/v3/health", defaultServiceHost, defaultServicePort)
+	require.NoError(t, client.Register(types.WithDryRun()))
+	require.NoError(t, client.Register())
+	require.NoError(t, client.RegisterCheck("health", "Health", "", checkUrl, "5s"))
+	checkErr := client.RegisterCheck("db", "Database", "", checkUrl, "5s")
+	require.ErrorIs(t, checkErr, types.ErrUnsupported)
+	require.NoError(t, client.UnregisterCheck("health"))
+	require.NoError(t, client.Unregister())
+
+	record := func(operation string, target string, err error) types.AuditRecord {
+		return types.AuditRecord{Actor: client.serviceKey, Operation: operation, Target: target, Timestamp: fakeClock.Now(), Err: err}
+	}
+	// Dry runs don't change anything, so they aren't audited
+	expected := []types.AuditRecord{
+		record("Register", client.serviceKey, nil),
+		record("RegisterCheck", "health", nil),
+		record("RegisterCheck", "db", checkErr),
+		record("UnregisterCheck", "health", nil),
+		record("Unregister", client.serviceKey, nil),
+	}
+	require.Equal(t, expected, sink.records)
+
+	// Re-registering under a new service key when reconfiguring is also audited
+	require.NoError(t, client.Register())
+	previousKey := client.serviceKey
+	newConfig := *client.config
+	newConfig.ServiceKey = getUniqueServiceName()
+	require.NoError(t, client.Reconfigure(newConfig))
+	defer func() {
+		_ = client.Unregister()
+	}()
+	expected = append(expected,
+		types.AuditRecord{Actor: previousKey, Operation: "Register", Target: previousKey, Timestamp: fakeClock.Now()},
+		types.AuditRecord{Actor: previousKey, Operation: "Unregister", Target: previousKey, Timestamp: fakeClock.Now()},
+		record("Register", client.serviceKey, nil),
+	)
+	require.Equal(t, expected, sink.records)
+}
+
+func TestUserAgent(t *testing.T) {
+	mock := keepertest.NewMockKeeper()
+	var lock sync.Mutex
+	var userAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		lock.Lock()
+		userAgents = append(userAgents, request.UserAgent())
+		lock.Unlock()
+		mock.Handler().ServeHTTP(writer, request)
+	}))
+	defer server.Close()
+
+	serverUrl, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverUrl.Port())
+
+	tests := []struct {
+		name      string
+		userAgent string
+		expected  string
+	}{
+		{"Default", "", types.Config{ServiceKey: "core-data"}.GetUserAgent()},
+		{"Overridden", "edgex-core-data/4.0", "edgex-core-data/4.0"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			client, err := NewKeeperClient(types.Config{
+				Host:         serverUrl.Hostname(),
+				Port:         serverPort,
+				ServiceKey:   "core-data",
+				UserAgent:    testCase.userAgent,
+				AuthInjector: NewNullAuthenticationInjector(),
+			})
+			require.NoError(t, err)
+
+			lock.Lock()
+			userAgents = nil
+			lock.Unlock()
+
+			require.True(t, client.IsAlive())
+			_, err = client.GetAllServiceEndpoints()
+			require.NoError(t, err)
+
+			lock.Lock()
+			defer lock.Unlock()
+			require.Equal(t, []string{testCase.expected, testCase.expected}, userAgents)
+		})
+	}
+}
+
+func TestFailoverHosts(t *testing.T) {
+	// The primary replica accepts the connections but drops them without responding
+	var primaryRequests atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		primaryRequests.Add(1)
+		conn, _, err := writer.(http.Hijacker).Hijack()
+		if err == nil {
+			_ = conn.Close()
+		}
+	}))
+	defer primary.Close()
+
+	mock := keepertest.NewMockKeeper()
+	replica := httptest.NewServer(mock.Handler())
+	defer replica.Close()
+
+	primaryUrl, _ := url.Parse(primary.URL)
+	primaryPort, _ := strconv.Atoi(primaryUrl.Port())
+	replicaUrl, _ := url.Parse(replica.URL)
+	client, err := NewKeeperClient(types.Config{
+		Host:          primaryUrl.Hostname(),
+		Port:          primaryPort,
+		FailoverHosts: []string{replicaUrl.Host},
+		ServiceKey:    getUniqueServiceName(),
+		ServiceHost:   defaultServiceHost,
+		ServicePort:   defaultServicePort,
+		CheckInterval: "10s",
+		CheckRoute:    "/api/v3/ping",
+		AuthInjector:  NewNullAuthenticationInjector(),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Register())
+	_, ok := mock.Registration(client.serviceKey)
+	require.True(t, ok)
+	require.Positive(t, primaryRequests.Load())
+
+	// The replica which worked is used first from then on
+	failedRequests := primaryRequests.Load()
+	require.True(t, client.IsAlive())
+	_, err = client.GetServiceEndpoint(client.serviceKey)
+	require.NoError(t, err)
+	require.Equal(t, failedRequests, primaryRequests.Load())
+
+	_, err = NewKeeperClient(types.Config{Host: "localhost", Port: 59890, FailoverHosts: []string{"bogus"}})
+	require.Error(t, err)
+}
@@ -1,12 +0,0 @@
-//
-// Copyright (C) 2024 IOTech Ltd
-//
-// SPDX-License-Identifier: Apache-2.0
-
-package keeper
-
-import "github.com/edgexfoundry/go-mod-core-contracts/v4/common"
-
-const (
-	ApiRegistrationByServiceIdRoute = common.ApiRegisterRoute + "/" + common.ServiceId + "/"
-)
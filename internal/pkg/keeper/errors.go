@@ -0,0 +1,32 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+// wrapError wraps the error returned by the core-contracts clients with the matching registry sentinel error, if any
+func wrapError(err errors.EdgeX) error {
+	switch {
+	case isUnauthorized(err):
+		return fmt.Errorf("%w: %w", types.ErrAccessDenied, err)
+	case err.Code() == http.StatusNotFound:
+		return fmt.Errorf("%w: %w", types.ErrServiceNotFound, err)
+	default:
+		return err
+	}
+}
+
+// isUnauthorized returns true when Keeper rejected the request due to missing or invalid credentials
+func isUnauthorized(err errors.EdgeX) bool {
+	return err.Code() == http.StatusUnauthorized || err.Code() == http.StatusForbidden
+}
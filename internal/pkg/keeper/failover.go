@@ -0,0 +1,71 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+// failover holds the addresses of the Keeper replicas and remembers the one which last worked, shared by the round
+// trippers built for every request
+type failover struct {
+	addresses []string
+	current   atomic.Int32
+	logger    logger.LoggingClient
+}
+
+func newFailover(addresses []string, logger logger.LoggingClient) *failover {
+	return &failover{addresses: addresses, logger: logger}
+}
+
+// failoverRoundTripper sends the requests to the Keeper replica which last worked, moving on to the next replica when
+// the connection fails. Responses, including errors returned by Keeper, are never failed over.
+type failoverRoundTripper struct {
+	next     http.RoundTripper
+	failover *failover
+}
+
+func (f *failoverRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := int(f.failover.current.Load())
+	var lastErr error
+	for i := range f.failover.addresses {
+		index := (start + i) % len(f.failover.addresses)
+		attempt := req.Clone(req.Context())
+		attempt.URL.Host = f.failover.addresses[index]
+		attempt.Host = ""
+		if i > 0 && req.Body != nil && req.Body != http.NoBody {
+			// The body was consumed by the previous attempt, so it can only be sent again if it can be recreated
+			if req.GetBody == nil {
+				return nil, lastErr
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, lastErr
+			}
+			attempt.Body = body
+		}
+
+		resp, err := f.next.RoundTrip(attempt)
+		if err == nil {
+			if index != start {
+				f.failover.logger.Infof("Failed over to Keeper at %s", f.failover.addresses[index])
+				f.failover.current.Store(int32(index))
+			}
+			return resp, nil
+		}
+		// No other replica is tried once the caller gave up
+		if req.Context().Err() != nil {
+			return nil, err
+		}
+		f.failover.logger.Warnf("Unable to connect to Keeper at %s: %v", f.failover.addresses[index], err)
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
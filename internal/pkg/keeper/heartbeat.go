@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import "context"
+
+// sendHeartbeat refreshes the last modified time of the registration of the current service, keeping the status
+// determined by the health check of Keeper so a failing service isn't reported as healthy
+func (k *keeperClient) sendHeartbeat(ctx context.Context) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	// The heartbeat may have been stopped, e.g. by de-registering, while waiting for the lock
+	if ctx.Err() != nil || !k.registered.Load() {
+		return
+	}
+
+	err := k.updateInPlace(k.selfRegistration())
+	if err != nil {
+		k.config.GetLogger().Warnf("Failed to send heartbeat of the %s service to Keeper: %v", k.serviceKey, err)
+	}
+}
@@ -0,0 +1,78 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+)
+
+func TestHeartbeat(t *testing.T) {
+	if mockKeeper == nil {
+		t.Skip("requires the mock Keeper to change the service status")
+	}
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.Clock = fakeClock
+	client.config.HeartbeatInterval = "30s"
+	defer client.Stop()
+
+	require.NoError(t, client.Register())
+	fakeClock.BlockUntil(1)
+
+	// Keeper's health check marked the service as down, which the heartbeat must not revert
+	registration, ok := mockKeeper.Registration(client.serviceKey)
+	require.True(t, ok)
+	registration.Status = models.Down
+	registration.Modified = 1
+	mockKeeper.SetRegistration(registration)
+
+	refreshed := func() bool {
+		registration, ok := mockKeeper.Registration(client.serviceKey)
+		return ok && registration.Modified != 1
+	}
+
+	fakeClock.Advance(30 * time.Second)
+	require.Eventually(t, refreshed, watchTimeout, 10*time.Millisecond)
+	registration, _ = mockKeeper.Registration(client.serviceKey)
+	require.Equal(t, models.Down, registration.Status)
+
+	// No heartbeat is sent once de-registered
+	require.NoError(t, client.Unregister())
+	registration, _ = mockKeeper.Registration(client.serviceKey)
+	registration.Modified = 1
+	mockKeeper.SetRegistration(registration)
+	fakeClock.Advance(30 * time.Second)
+	require.Never(t, refreshed, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestHeartbeatDisabled(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+
+	// Try to clean-up after test
+	defer func() {
+		_ = client.Unregister()
+	}()
+
+	require.NoError(t, client.Register())
+	require.Nil(t, client.heartbeat)
+	client.Stop()
+}
+
+func TestInvalidHeartbeatInterval(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.HeartbeatInterval = "bogus"
+
+	_, err := NewKeeperClient(*client.config)
+	require.Error(t, err)
+}
@@ -0,0 +1,231 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/internal/pkg/ratelimit"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/retry"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+const backendType = "keeper"
+
+// invoker applies the behavior shared by every call to the Keeper APIs, such as the configured retry policy, rate limits and metrics
+type invoker struct {
+	config       *types.Config
+	readLimiter  *ratelimit.Limiter
+	writeLimiter *ratelimit.Limiter
+	stats        *clientStats
+	// renewAccessToken and renewExpiringAccessToken are nil when no GetAccessToken callback is configured
+	renewAccessToken         func() error
+	renewExpiringAccessToken func() error
+}
+
+func newInvoker(config *types.Config, injector *transportInjector, stats *clientStats) *invoker {
+	i := &invoker{
+		config:       config,
+		stats:        stats,
+		readLimiter:  ratelimit.NewLimiter(config.RateLimit.ReadsPerSecond, config.RateLimit.Burst, config.GetClock()),
+		writeLimiter: ratelimit.NewLimiter(config.RateLimit.WritesPerSecond, config.RateLimit.Burst, config.GetClock()),
+	}
+	if config.GetAccessToken != nil {
+		i.renewAccessToken = injector.renewAccessToken
+		i.renewExpiringAccessToken = injector.renewExpiringAccessToken
+	}
+
+	return i
+}
+
+// invoke calls the Keeper API, retrying as configured when the error indicates Keeper is unavailable or failed internally.
+// Errors caused by the request itself, such as not found, are returned immediately. Every attempt waits for the limiter.
+// When Keeper rejects the request as unauthorized, the access token is renewed and the request sent once more, if configured.
+// The access token is also renewed before sending the request if it is about to expire, if configured.
+func invoke[T any](i *invoker, ctx context.Context, operation string, limiter *ratelimit.Limiter,
+	call func(ctx context.Context) (T, errors.EdgeX)) (T, errors.EdgeX) {
+	var result T
+	var edgexErr errors.EdgeX
+	renewed := false
+
+	notify := func(err error, attempt int, wait time.Duration) {
+		i.config.GetLogger().Warnf("Keeper %s attempt %d failed, retrying in %s: %v", operation, attempt, wait, err)
+	}
+
+	// The current access token is still used if renewing it ahead of its expiry fails
+	if i.renewExpiringAccessToken != nil {
+		if err := i.renewExpiringAccessToken(); err != nil {
+			i.config.GetLogger().Warnf("Keeper %s: %v", operation, err)
+		}
+	}
+
+	_ = retry.DoNotify(ctx, i.config.RetryPolicy, i.config.GetClock(), func(ctx context.Context) error {
+		if err := limiter.Wait(ctx); err != nil {
+			// The request was given up by the caller, so this is not reported as Keeper being unavailable
+			edgexErr = errors.NewCommonEdgeX(errors.KindContractInvalid, "request cancelled while waiting for the rate limit", err)
+			return retry.Permanent(edgexErr)
+		}
+
+		result, edgexErr = measure(i, operation, ctx, call)
+		if edgexErr != nil && isUnauthorized(edgexErr) && i.renewAccessToken != nil && !renewed {
+			renewed = true
+			if err := i.renewAccessToken(); err != nil {
+				i.config.GetLogger().Warnf("Keeper %s unauthorized: %v", operation, err)
+				return retry.Permanent(edgexErr)
+			}
+			result, edgexErr = measure(i, operation, ctx, call)
+		}
+		if edgexErr == nil {
+			return nil
+		}
+		if edgexErr.Code() < http.StatusInternalServerError {
+			return retry.Permanent(edgexErr)
+		}
+		return edgexErr
+	}, notify)
+
+	return result, edgexErr
+}
+
+// measure calls the Keeper API, recording the outcome in the client stats and reporting the call to the
+// MetricsReporter if configured
+func measure[T any](i *invoker, operation string, ctx context.Context,
+	call func(ctx context.Context) (T, errors.EdgeX)) (T, errors.EdgeX) {
+	start := i.config.GetClock().Now()
+	result, edgexErr := call(ctx)
+	i.stats.recordCall(i.config.GetClock().Now(), edgexErr)
+	if i.config.MetricsReporter != nil {
+		var err error
+		if edgexErr != nil {
+			err = edgexErr
+		}
+		i.config.MetricsReporter.ReportCall(backendType, operation, i.config.GetClock().Since(start), err)
+	}
+
+	return result, edgexErr
+}
+
+// invokeNoResult is the same as invoke for the Keeper APIs that only return an error
+func invokeNoResult(i *invoker, ctx context.Context, operation string, limiter *ratelimit.Limiter,
+	call func(ctx context.Context) errors.EdgeX) errors.EdgeX {
+	_, err := invoke(i, ctx, operation, limiter, func(ctx context.Context) (any, errors.EdgeX) {
+		return nil, call(ctx)
+	})
+	return err
+}
+
+// registryClient decorates the core-contracts RegistryClient so every call goes through the invoker. The configured
+// Namespace is also applied here, prefixing the service keys sent to Keeper and stripping them from the responses.
+type registryClient struct {
+	invoker *invoker
+	client  interfaces.RegistryClient
+}
+
+func (r *registryClient) Register(ctx context.Context, req requests.AddRegistrationRequest) errors.EdgeX {
+	req.Registration.ServiceId = r.invoker.config.GetNamespacedKey(req.Registration.ServiceId)
+	return invokeNoResult(r.invoker, ctx, "Register", r.invoker.writeLimiter, func(ctx context.Context) errors.EdgeX {
+		return r.client.Register(ctx, req)
+	})
+}
+
+func (r *registryClient) UpdateRegister(ctx context.Context, req requests.AddRegistrationRequest) errors.EdgeX {
+	req.Registration.ServiceId = r.invoker.config.GetNamespacedKey(req.Registration.ServiceId)
+	return invokeNoResult(r.invoker, ctx, "UpdateRegister", r.invoker.writeLimiter, func(ctx context.Context) errors.EdgeX {
+		return r.client.UpdateRegister(ctx, req)
+	})
+}
+
+func (r *registryClient) RegistrationByServiceId(ctx context.Context, serviceId string) (responses.RegistrationResponse, errors.EdgeX) {
+	resp, err := invoke(r.invoker, ctx, "RegistrationByServiceId", r.invoker.readLimiter, func(ctx context.Context) (responses.RegistrationResponse, errors.EdgeX) {
+		return r.client.RegistrationByServiceId(ctx, r.invoker.config.GetNamespacedKey(serviceId))
+	})
+	if err == nil {
+		resp.Registration.ServiceId, _ = r.invoker.config.StripNamespace(resp.Registration.ServiceId)
+	}
+	return resp, err
+}
+
+func (r *registryClient) AllRegistry(ctx context.Context, deregistered bool) (responses.MultiRegistrationsResponse, errors.EdgeX) {
+	resp, err := invoke(r.invoker, ctx, "AllRegistry", r.invoker.readLimiter, func(ctx context.Context) (responses.MultiRegistrationsResponse, errors.EdgeX) {
+		return r.client.AllRegistry(ctx, deregistered)
+	})
+	if err != nil || r.invoker.config.Namespace == "" {
+		return resp, err
+	}
+
+	// The services of other namespaces are left out, also from the total count so they aren't reported as missing
+	registrations := make([]dtos.Registration, 0, len(resp.Registrations))
+	for _, registration := range resp.Registrations {
+		serviceId, inNamespace := r.invoker.config.StripNamespace(registration.ServiceId)
+		if !inNamespace {
+			continue
+		}
+		registration.ServiceId = serviceId
+		registrations = append(registrations, registration)
+	}
+	resp.TotalCount -= uint32(len(resp.Registrations) - len(registrations))
+	resp.Registrations = registrations
+	return resp, nil
+}
+
+func (r *registryClient) Deregister(ctx context.Context, serviceId string) errors.EdgeX {
+	return invokeNoResult(r.invoker, ctx, "Deregister", r.invoker.writeLimiter, func(ctx context.Context) errors.EdgeX {
+		return r.client.Deregister(ctx, r.invoker.config.GetNamespacedKey(serviceId))
+	})
+}
+
+// commonClient decorates the core-contracts CommonClient so every call goes through the invoker
+type commonClient struct {
+	invoker *invoker
+	client  interfaces.CommonClient
+}
+
+func (c *commonClient) Configuration(ctx context.Context) (common.ConfigResponse, errors.EdgeX) {
+	return invoke(c.invoker, ctx, "Configuration", c.invoker.readLimiter, func(ctx context.Context) (common.ConfigResponse, errors.EdgeX) {
+		return c.client.Configuration(ctx)
+	})
+}
+
+func (c *commonClient) Ping(ctx context.Context) (common.PingResponse, errors.EdgeX) {
+	return invoke(c.invoker, ctx, "Ping", c.invoker.readLimiter, func(ctx context.Context) (common.PingResponse, errors.EdgeX) {
+		return c.client.Ping(ctx)
+	})
+}
+
+// timedPing is the same as Ping, also returning how long the last attempt took. The time spent waiting for the limiter,
+// between retries and renewing the access token isn't included, so only the round trip to Keeper is timed.
+func (c *commonClient) timedPing(ctx context.Context) (time.Duration, errors.EdgeX) {
+	clk := c.invoker.config.GetClock()
+	var latency time.Duration
+	_, err := invoke(c.invoker, ctx, "Ping", c.invoker.readLimiter, func(ctx context.Context) (common.PingResponse, errors.EdgeX) {
+		start := clk.Now()
+		resp, err := c.client.Ping(ctx)
+		latency = clk.Since(start)
+		return resp, err
+	})
+	return latency, err
+}
+
+func (c *commonClient) Version(ctx context.Context) (common.VersionResponse, errors.EdgeX) {
+	return invoke(c.invoker, ctx, "Version", c.invoker.readLimiter, func(ctx context.Context) (common.VersionResponse, errors.EdgeX) {
+		return c.client.Version(ctx)
+	})
+}
+
+func (c *commonClient) AddSecret(ctx context.Context, request common.SecretRequest) (common.BaseResponse, errors.EdgeX) {
+	return invoke(c.invoker, ctx, "AddSecret", c.invoker.writeLimiter, func(ctx context.Context) (common.BaseResponse, errors.EdgeX) {
+		return c.client.AddSecret(ctx, request)
+	})
+}
@@ -0,0 +1,51 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"net/http"
+)
+
+// reconcile checks the registration of the current service in Keeper still matches the requested one, re-applying it
+// if it drifted, e.g. after it was edited, or registering the service again if it is gone, e.g. after Keeper restarted
+// without persistence
+func (k *keeperClient) reconcile(ctx context.Context) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	// The reconciliation may have been stopped, e.g. by de-registering, while waiting for the lock
+	if ctx.Err() != nil || !k.registered.Load() {
+		return
+	}
+
+	expected := registrationRequest(k.selfRegistration(), "")
+	resp, err := k.registryClient.RegistrationByServiceId(context.Background(), k.serviceKey)
+	notFound := resp.StatusCode == http.StatusNotFound || (err != nil && err.Code() == http.StatusNotFound)
+	if err != nil && !notFound {
+		k.config.GetLogger().Warnf("Failed to reconcile the %s service registration with Keeper: %v", k.serviceKey, err)
+		return
+	}
+
+	if notFound {
+		k.config.GetLogger().Infof("The %s service registration is missing from Keeper, registering again", k.serviceKey)
+		err = k.registryClient.Register(context.Background(), expected)
+	} else {
+		actual := resp.Registration
+		if actual.Host == expected.Registration.Host && actual.Port == expected.Registration.Port &&
+			actual.HealthCheck == expected.Registration.HealthCheck {
+			return
+		}
+
+		// The status determined by the health check of Keeper isn't part of the drift
+		expected.Registration.Status = actual.Status
+		k.config.GetLogger().Infof("The %s service registration drifted in Keeper, re-applying it", k.serviceKey)
+		err = k.registryClient.UpdateRegister(context.Background(), expected)
+	}
+	if err != nil {
+		k.config.GetLogger().Warnf("Failed to reconcile the %s service registration with Keeper: %v", k.serviceKey, err)
+	}
+}
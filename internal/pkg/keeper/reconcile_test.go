@@ -0,0 +1,67 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+)
+
+func TestReconcile(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.Clock = fakeClock
+	client.config.ReconcileInterval = "5m"
+	defer client.Stop()
+
+	// Try to clean-up after test
+	defer func() {
+		_ = client.Unregister()
+	}()
+
+	require.NoError(t, client.Register())
+	fakeClock.BlockUntil(1)
+
+	registeredPort := func() int {
+		resp, err := client.registryClient.RegistrationByServiceId(context.Background(), client.serviceKey)
+		if err != nil {
+			return 0
+		}
+		return resp.Registration.Port
+	}
+
+	// Drifted registration is re-applied, keeping the status Keeper holds
+	drifted := client.selfRegistration()
+	drifted.Port = defaultServicePort + 1
+	require.NoError(t, client.registryClient.UpdateRegister(context.Background(), registrationRequest(drifted, models.Up)))
+	require.Equal(t, defaultServicePort+1, registeredPort())
+	fakeClock.Advance(5 * time.Minute)
+	require.Eventually(t, func() bool { return registeredPort() == defaultServicePort }, watchTimeout, 10*time.Millisecond)
+	resp, err := client.registryClient.RegistrationByServiceId(context.Background(), client.serviceKey)
+	require.NoError(t, err)
+	require.Equal(t, models.Up, resp.Registration.Status)
+
+	// Missing registration is registered again
+	require.NoError(t, client.registryClient.Deregister(context.Background(), client.serviceKey))
+	require.Zero(t, registeredPort())
+	fakeClock.Advance(5 * time.Minute)
+	require.Eventually(t, func() bool { return registeredPort() == defaultServicePort }, watchTimeout, 10*time.Millisecond)
+}
+
+func TestInvalidReconcileInterval(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.ReconcileInterval = "bogus"
+
+	_, err := NewKeeperClient(*client.config)
+	require.Error(t, err)
+}
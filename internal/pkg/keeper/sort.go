@@ -0,0 +1,62 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+// sortRegistrations orders the registrations in place. Keeper doesn't guarantee the order of the registrations it
+// returns, so they are always sorted by service ID first to make every ordering deterministic.
+func sortRegistrations(registrations []dtos.Registration, order types.EndpointOrder, seed uint64) {
+	slices.SortFunc(registrations, func(a, b dtos.Registration) int {
+		return strings.Compare(a.ServiceId, b.ServiceId)
+	})
+
+	switch order {
+	case types.EndpointOrderHealth:
+		slices.SortStableFunc(registrations, func(a, b dtos.Registration) int {
+			return healthRank(a) - healthRank(b)
+		})
+	case types.EndpointOrderLastUpdated:
+		slices.SortStableFunc(registrations, func(a, b dtos.Registration) int {
+			return cmp.Compare(lastUpdated(b), lastUpdated(a))
+		})
+	case types.EndpointOrderRandom:
+		random := rand.New(rand.NewPCG(seed, seed)) // #nosec G404 -- ordering does not require a secure random source
+		random.Shuffle(len(registrations), func(i, j int) {
+			registrations[i], registrations[j] = registrations[j], registrations[i]
+		})
+	}
+}
+
+// lastUpdated returns when the registration last changed, in milliseconds, with Modified only set once the registration
+// has been updated
+func lastUpdated(registration dtos.Registration) int64 {
+	if registration.Modified != 0 {
+		return registration.Modified
+	}
+	return registration.Created
+}
+
+func healthRank(registration dtos.Registration) int {
+	switch strings.ToUpper(registration.Status) {
+	case models.Up:
+		return 0
+	case models.Down:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,66 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+func TestSortRegistrations(t *testing.T) {
+	newRegistrations := func() []dtos.Registration {
+		return []dtos.Registration{
+			{ServiceId: "core-data", Status: models.Down, DBTimestamp: dtos.DBTimestamp{Created: 1000, Modified: 4000}},
+			{ServiceId: "core-command", Status: models.Up, DBTimestamp: dtos.DBTimestamp{Created: 3000}},
+			{ServiceId: "core-metadata", Status: models.Unknown, DBTimestamp: dtos.DBTimestamp{Created: 2000}},
+			{ServiceId: "app-rules-engine", Status: models.Up, DBTimestamp: dtos.DBTimestamp{Created: 2000}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		order    types.EndpointOrder
+		expected []string
+	}{
+		{"Default", "", []string{"app-rules-engine", "core-command", "core-data", "core-metadata"}},
+		{"Service ID", types.EndpointOrderServiceId, []string{"app-rules-engine", "core-command", "core-data", "core-metadata"}},
+		{"Health", types.EndpointOrderHealth, []string{"app-rules-engine", "core-command", "core-metadata", "core-data"}},
+		{"Last updated", types.EndpointOrderLastUpdated, []string{"core-data", "core-command", "app-rules-engine", "core-metadata"}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			registrations := newRegistrations()
+			sortRegistrations(registrations, testCase.order, 0)
+			assert.Equal(t, testCase.expected, serviceIds(registrations))
+		})
+	}
+
+	t.Run("Random with seed", func(t *testing.T) {
+		first := newRegistrations()
+		sortRegistrations(first, types.EndpointOrderRandom, 42)
+
+		second := newRegistrations()
+		second[0], second[3] = second[3], second[0]
+		sortRegistrations(second, types.EndpointOrderRandom, 42)
+
+		assert.Equal(t, serviceIds(first), serviceIds(second), "same seed should give the same order regardless of input order")
+	})
+}
+
+func serviceIds(registrations []dtos.Registration) []string {
+	ids := make([]string, len(registrations))
+	for i, r := range registrations {
+		ids[i] = r.ServiceId
+	}
+	return ids
+}
@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+// clientStats tracks the connectivity of the client to Keeper across all the calls
+type clientStats struct {
+	lock  sync.Mutex
+	stats types.ClientStats
+}
+
+// recordCall records the outcome of a call to Keeper. Errors caused by the request itself, such as not found, still
+// count as Keeper having responded, the same as when deciding whether to retry. Being unauthorized counts as a failure
+// though, since no call succeeds until the access token is fixed.
+func (s *clientStats) recordCall(at time.Time, err errors.EdgeX) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err == nil || (err.Code() < http.StatusInternalServerError && !isUnauthorized(err)) {
+		s.stats.LastSuccess = at
+		s.stats.ConsecutiveFailures = 0
+		return
+	}
+
+	s.stats.LastFailure = at
+	s.stats.ConsecutiveFailures++
+}
+
+func (s *clientStats) recordTokenRenewal() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.stats.TokenRenewals++
+}
+
+func (s *clientStats) snapshot() types.ClientStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.stats
+}
+
+// restore carries the stats over from the stats of a previous client, e.g. when reconfiguring
+func (s *clientStats) restore(previous *clientStats) {
+	stats := previous.snapshot()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.stats = stats
+}
@@ -0,0 +1,73 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenFile provides the access token read from a file, such as a mounted Kubernetes secret or a Vault agent sink.
+// The file is read again whenever its modification time changes, so rotated tokens are picked up without a restart.
+type tokenFile struct {
+	path string
+
+	lock    sync.Mutex
+	modTime time.Time
+	size    int64
+	token   string
+}
+
+func newTokenFile(path string) (*tokenFile, error) {
+	file := &tokenFile{path: path}
+	if _, err := file.current(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// current returns the access token, reading the file again if it changed since it was last read.
+// The last token read is returned if the file can no longer be read, e.g. while it is being replaced.
+func (f *tokenFile) current() (string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		if f.token != "" {
+			return f.token, nil
+		}
+		return "", fmt.Errorf("failed to read access token file %s: %v", f.path, err)
+	}
+	if f.token != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.token, nil
+	}
+
+	contents, err := os.ReadFile(f.path)
+	if err != nil {
+		if f.token != "" {
+			return f.token, nil
+		}
+		return "", fmt.Errorf("failed to read access token file %s: %v", f.path, err)
+	}
+
+	token := strings.TrimSpace(string(contents))
+	if token == "" {
+		if f.token != "" {
+			return f.token, nil
+		}
+		return "", fmt.Errorf("access token file %s is empty", f.path)
+	}
+
+	f.token = token
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	return f.token, nil
+}
@@ -0,0 +1,77 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+func TestTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	writeToken := func(token string, modTime time.Time) {
+		require.NoError(t, os.WriteFile(path, []byte(token), 0600))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	_, err := newTokenFile(path)
+	require.Error(t, err, "Expected error due to missing token file")
+
+	writeToken("first-token\n", time.Now().Add(-time.Minute))
+	file, err := newTokenFile(path)
+	require.NoError(t, err)
+
+	token, err := file.current()
+	require.NoError(t, err)
+	require.Equal(t, "first-token", token)
+
+	// Rotated token is picked up
+	writeToken("second-token", time.Now())
+	token, err = file.current()
+	require.NoError(t, err)
+	require.Equal(t, "second-token", token)
+
+	// Last token is kept while the file is being replaced
+	require.NoError(t, os.Remove(path))
+	token, err = file.current()
+	require.NoError(t, err)
+	require.Equal(t, "second-token", token)
+}
+
+func TestAccessTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("file-token"), 0600))
+
+	injector, err := newTransportInjector(types.Config{AccessTokenFile: path, AuthInjector: NewNullAuthenticationInjector()}, &clientStats{})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+	require.NoError(t, injector.AddAuthenticationData(req))
+	require.Equal(t, "Bearer file-token", req.Header.Get("Authorization"))
+
+	// A renewed token is used until the file changes
+	injector.getAccessToken = func() (string, error) { return "renewed-token", nil }
+	require.NoError(t, injector.renewAccessToken())
+	require.NoError(t, injector.AddAuthenticationData(req))
+	require.Equal(t, "Bearer renewed-token", req.Header.Get("Authorization"))
+
+	require.NoError(t, os.WriteFile(path, []byte("rotated-token"), 0600))
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	require.NoError(t, injector.AddAuthenticationData(req))
+	require.Equal(t, "Bearer rotated-token", req.Header.Get("Authorization"))
+
+	_, err = NewKeeperClient(types.Config{AccessTokenFile: filepath.Join(t.TempDir(), "missing")})
+	require.Error(t, err, "Expected error due to missing token file")
+}
@@ -0,0 +1,345 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+// transportInjector wraps the configured AuthenticationInjector so the core-contracts clients
+// send their requests to Keeper through the transport built from the registry configuration.
+// When an access token file or a GetAccessToken callback is configured, the access token is added to every request.
+type transportInjector struct {
+	authInjector interfaces.AuthenticationInjector
+	transport    http.RoundTripper
+	// defaultTransport is used when neither the registry configuration nor the AuthInjector provide a transport
+	defaultTransport http.RoundTripper
+	requestTimeout   time.Duration
+	userAgent        string
+	// failover is nil unless FailoverHosts are configured
+	failover       *failover
+	tokenFile      *tokenFile
+	getAccessToken types.GetAccessTokenCallback
+	renewBefore    time.Duration
+	tokenTTL       time.Duration
+	clock          clock.Clock
+	stats          *clientStats
+
+	// renewLock prevents concurrent requests from renewing an expiring access token more than once
+	renewLock   sync.Mutex
+	tokenLock   sync.RWMutex
+	accessToken string
+	tokenExpiry time.Time
+	// renewedOver is the token read from the token file when the access token was renewed, so the renewed access
+	// token is only used until the token file changes
+	renewedOver string
+}
+
+func newTransportInjector(registryConfig types.Config, stats *clientStats) (*transportInjector, error) {
+	injector := &transportInjector{
+		authInjector:   registryConfig.AuthInjector,
+		getAccessToken: registryConfig.GetAccessToken,
+		clock:          registryConfig.GetClock(),
+		stats:          stats,
+		userAgent:      registryConfig.GetUserAgent(),
+	}
+
+	var err error
+	if injector.renewBefore, err = registryConfig.GetAccessTokenRenewBefore(); err != nil {
+		return nil, fmt.Errorf("unable to create Keeper transport: %v", err)
+	}
+	if injector.tokenTTL, err = registryConfig.GetAccessTokenTTL(); err != nil {
+		return nil, fmt.Errorf("unable to create Keeper transport: %v", err)
+	}
+	if injector.requestTimeout, err = registryConfig.HttpTransport.GetRequestTimeout(); err != nil {
+		return nil, fmt.Errorf("unable to create Keeper transport: %v", err)
+	}
+
+	addresses, err := registryConfig.GetRegistryAddresses()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Keeper transport: %v", err)
+	}
+	if len(addresses) > 1 {
+		injector.failover = newFailover(addresses, registryConfig.GetLogger())
+	}
+
+	if registryConfig.AccessTokenFile != "" {
+		file, err := newTokenFile(registryConfig.AccessTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create Keeper transport: %v", err)
+		}
+		injector.tokenFile = file
+	}
+
+	// A caller provided client or transport, the unix socket and the TLS settings take precedence over any transport provided by the AuthInjector
+	switch {
+	case registryConfig.HttpClient != nil:
+		injector.transport = &clientRoundTripper{client: registryConfig.HttpClient}
+	case registryConfig.Transport != nil:
+		injector.transport = registryConfig.Transport
+	case registryConfig.IsUnixSocket():
+		// The connection to a unix socket is never secured with TLS, so the TLS settings would be silently ignored
+		if registryConfig.TLSConfig.IsEnabled() {
+			return nil, fmt.Errorf("unable to create Keeper transport: TLS is not supported with the %s protocol", types.UnixProtocol)
+		}
+		socketPath := registryConfig.Host
+		transport, err := registryConfig.HttpTransport.BuildTransport()
+		if err != nil {
+			return nil, fmt.Errorf("unable to create Keeper transport: %v", err)
+		}
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socketPath)
+		}
+		injector.transport = transport
+	default:
+		tlsConfig, err := registryConfig.TLSConfig.BuildTLSConfig()
+		if err != nil {
+			return nil, fmt.Errorf("unable to create Keeper transport: %v", err)
+		}
+
+		transport, err := registryConfig.HttpTransport.BuildTransport()
+		if err != nil {
+			return nil, fmt.Errorf("unable to create Keeper transport: %v", err)
+		}
+
+		if tlsConfig != nil {
+			transport.TLSClientConfig = tlsConfig
+			injector.transport = transport
+		} else {
+			// Only used if the AuthInjector doesn't provide a transport
+			injector.defaultTransport = transport
+		}
+	}
+
+	return injector, nil
+}
+
+// AddAuthenticationData adds the authentication data from the wrapped AuthenticationInjector, if any, replacing the
+// authorization with the access token from the token file, or the renewed access token if one was obtained since the
+// token file last changed.
+// As it is called for every request, the User-Agent is also set here.
+func (t *transportInjector) AddAuthenticationData(req *http.Request) error {
+	req.Header.Set("User-Agent", t.userAgent)
+
+	if t.authInjector != nil {
+		if err := t.authInjector.AddAuthenticationData(req); err != nil {
+			return err
+		}
+	}
+
+	t.tokenLock.RLock()
+	accessToken := t.accessToken
+	renewedOver := t.renewedOver
+	t.tokenLock.RUnlock()
+
+	// The most recently obtained token is used, i.e. the renewed access token until the token file changes
+	if t.tokenFile != nil {
+		fileToken, err := t.tokenFile.current()
+		switch {
+		case err != nil && accessToken == "":
+			return err
+		case err == nil && (accessToken == "" || fileToken != renewedOver):
+			accessToken = fileToken
+		}
+	}
+
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	return nil
+}
+
+// renewAccessToken obtains a new access token using the GetAccessToken callback
+func (t *transportInjector) renewAccessToken() error {
+	token, err := t.getAccessToken()
+	if err != nil {
+		return fmt.Errorf("failed to renew access token: %v", err)
+	}
+
+	expiry := tokenExpiry(token)
+	if expiry.IsZero() && t.tokenTTL > 0 {
+		expiry = t.clock.Now().Add(t.tokenTTL)
+	}
+
+	var renewedOver string
+	if t.tokenFile != nil {
+		renewedOver, _ = t.tokenFile.current()
+	}
+
+	t.tokenLock.Lock()
+	defer t.tokenLock.Unlock()
+
+	t.accessToken = token
+	t.tokenExpiry = expiry
+	t.renewedOver = renewedOver
+	t.stats.recordTokenRenewal()
+	return nil
+}
+
+// renewExpiringAccessToken obtains a new access token using the GetAccessToken callback when none has been obtained
+// yet or the current one expires within the configured AccessTokenRenewBefore. Nothing is done if not configured.
+func (t *transportInjector) renewExpiringAccessToken() error {
+	if t.getAccessToken == nil || t.renewBefore == 0 {
+		return nil
+	}
+
+	t.renewLock.Lock()
+	defer t.renewLock.Unlock()
+
+	t.tokenLock.RLock()
+	accessToken := t.accessToken
+	expiry := t.tokenExpiry
+	t.tokenLock.RUnlock()
+
+	if accessToken != "" && (expiry.IsZero() || t.clock.Now().Before(expiry.Add(-t.renewBefore))) {
+		return nil
+	}
+
+	return t.renewAccessToken()
+}
+
+// restoreAccessToken carries over the access token renewed by the transport of a previous client, e.g. when reconfiguring
+func (t *transportInjector) restoreAccessToken(previous *transportInjector) {
+	previous.tokenLock.RLock()
+	accessToken := previous.accessToken
+	expiry := previous.tokenExpiry
+	renewedOver := previous.renewedOver
+	previous.tokenLock.RUnlock()
+
+	t.tokenLock.Lock()
+	defer t.tokenLock.Unlock()
+
+	t.accessToken = accessToken
+	t.tokenExpiry = expiry
+	t.renewedOver = renewedOver
+}
+
+// tokenExpiry returns the expiry from the exp claim of the JWT token, or zero if the token isn't a JWT with an expiry
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp <= 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(claims.Exp, 0)
+}
+
+// RoundTripper returns the transport built from the registry configuration, falling back to the one
+// provided by the wrapped AuthenticationInjector, if any. The same transport is used for every request so the
+// connections to Keeper are reused. Requests are limited to the configured request timeout, if any, and failed over
+// to the other Keeper replicas when FailoverHosts are configured.
+func (t *transportInjector) RoundTripper() http.RoundTripper {
+	transport := t.transport
+	if transport == nil && t.authInjector != nil {
+		transport = t.authInjector.RoundTripper()
+	}
+	if transport == nil {
+		transport = t.defaultTransport
+	}
+
+	if t.requestTimeout == 0 && t.failover == nil {
+		return transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if t.requestTimeout != 0 {
+		transport = &timeoutRoundTripper{next: transport, timeout: t.requestTimeout}
+	}
+	// Failing over wraps the timeout so a replica which doesn't respond in time is also failed over
+	if t.failover != nil {
+		transport = &failoverRoundTripper{next: transport, failover: t.failover}
+	}
+	return transport
+}
+
+// timeoutRoundTripper limits the time taken by each request, including reading the response body, as the core-contracts
+// clients don't send the requests with the caller's context
+type timeoutRoundTripper struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases the timeout of the request once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// clientRoundTripper adapts a caller provided http.Client to the http.RoundTripper expected by the core-contracts clients
+type clientRoundTripper struct {
+	client *http.Client
+}
+
+func (c *clientRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return c.client.Do(req)
+}
+
+// tlsError returns the TLS error which caused the request to fail, if any
+func tlsError(err error) error {
+	var verificationErr *tls.CertificateVerificationError
+	if errors.As(err, &verificationErr) {
+		return verificationErr
+	}
+
+	var recordHeaderErr tls.RecordHeaderError
+	if errors.As(err, &recordHeaderErr) {
+		return recordHeaderErr
+	}
+
+	var alertErr tls.AlertError
+	if errors.As(err, &alertErr) {
+		return alertErr
+	}
+
+	return nil
+}
@@ -0,0 +1,285 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+// SubscribeHealthEvents polls Keeper at the configured watch interval and calls the callback whenever the target service
+// transitions between healthy and unhealthy. The first poll only establishes the current health. Polls failing to reach
+// Keeper are skipped, so transitions are only reported based on what Keeper actually returned. When a ChangeNotifier is
+// configured, Keeper is also polled whenever a change to the target service is notified.
+func (k *keeperClient) SubscribeHealthEvents(serviceKey string, callback func(types.HealthEvent)) (func(), error) {
+	k.lock.RLock()
+	interval, err := k.config.GetWatchInterval()
+	clk := k.config.GetClock()
+	notifier := k.config.ChangeNotifier
+	// Keeper notifies the changes using the namespaced key
+	notifiedKey := k.config.GetNamespacedKey(serviceKey)
+	k.lock.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	changed, err := subscribeChanges(ctx, notifier, notifiedKey)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		defer close(done)
+
+		var healthy bool
+		initialized := false
+		poll := func() {
+			status, starting, ok := k.pollStatus(serviceKey)
+			if !ok {
+				return
+			}
+
+			current := strings.EqualFold(status, models.Up)
+			if starting && !current {
+				// Not reported as unhealthy until the health check grace period has elapsed
+				return
+			}
+			if initialized && current != healthy {
+				callback(types.HealthEvent{
+					ServiceId: serviceKey,
+					Healthy:   current,
+					Status:    status,
+					Timestamp: clk.Now(),
+				})
+			}
+			healthy = current
+			initialized = true
+		}
+
+		pollLoop(ctx, clk, interval, changed, poll)
+	}()
+
+	unsubscribe := func() {
+		cancel()
+		<-done
+	}
+
+	return unsubscribe, nil
+}
+
+// WatchAllServices polls Keeper at the configured watch interval and sends all the service endpoints on the updates
+// channel whenever any registration changed, starting with the current endpoints. Polls failing to reach Keeper, or
+// only returning part of the registrations, are reported on the errs channel, if not nil, and otherwise skipped.
+// When a ChangeNotifier is configured, Keeper is also polled whenever a change to any service is notified.
+func (k *keeperClient) WatchAllServices(updates chan<- []types.ServiceEndpoint, errs chan<- error) (func(), error) {
+	k.lock.RLock()
+	interval, err := k.config.GetWatchInterval()
+	clk := k.config.GetClock()
+	notifier := k.config.ChangeNotifier
+	k.lock.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	changed, err := subscribeChanges(ctx, notifier, "")
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		defer close(done)
+
+		var previous []types.ServiceEndpoint
+		initialized := false
+		poll := func() {
+			endpoints, err := k.GetAllServiceEndpoints()
+			if err != nil {
+				if errs != nil {
+					select {
+					case errs <- err:
+					case <-ctx.Done():
+					}
+				}
+				return
+			}
+
+			if initialized && reflect.DeepEqual(endpoints, previous) {
+				return
+			}
+
+			select {
+			case updates <- endpoints:
+				previous = endpoints
+				initialized = true
+			case <-ctx.Done():
+			}
+		}
+
+		pollLoop(ctx, clk, interval, changed, poll)
+	}()
+
+	unsubscribe := func() {
+		cancel()
+		<-done
+	}
+
+	return unsubscribe, nil
+}
+
+// SubscribeRegistrationEvents polls Keeper at the configured watch interval and calls the callback for every service
+// added to or removed from Keeper since the previous poll. The first poll only establishes the registered services.
+// Polls failing to reach Keeper, or only returning part of the registrations, are skipped so services aren't reported
+// as removed when they are only missing from the response. When a ChangeNotifier is configured, Keeper is also polled
+// whenever a change to any service is notified.
+func (k *keeperClient) SubscribeRegistrationEvents(callback func(types.RegistrationEvent)) (func(), error) {
+	k.lock.RLock()
+	interval, err := k.config.GetWatchInterval()
+	clk := k.config.GetClock()
+	notifier := k.config.ChangeNotifier
+	k.lock.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	changed, err := subscribeChanges(ctx, notifier, "")
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		defer close(done)
+
+		var previous []types.ServiceEndpoint
+		initialized := false
+		poll := func() {
+			endpoints, err := k.GetAllServiceEndpoints()
+			if err != nil {
+				k.config.GetLogger().Warnf("Failed to poll the registrations from Keeper: %v", err)
+				return
+			}
+
+			if initialized {
+				now := clk.Now()
+				for _, endpoint := range endpoints {
+					if !slices.ContainsFunc(previous, sameInstance(endpoint)) {
+						callback(types.RegistrationEvent{Registered: true, Endpoint: endpoint, Timestamp: now})
+					}
+				}
+				for _, endpoint := range previous {
+					if !slices.ContainsFunc(endpoints, sameInstance(endpoint)) {
+						callback(types.RegistrationEvent{Registered: false, Endpoint: endpoint, Timestamp: now})
+					}
+				}
+			}
+			previous = endpoints
+			initialized = true
+		}
+
+		pollLoop(ctx, clk, interval, changed, poll)
+	}()
+
+	unsubscribe := func() {
+		cancel()
+		<-done
+	}
+
+	return unsubscribe, nil
+}
+
+// pollLoop calls poll right away, then at every interval and whenever a change is notified, until the context is done
+func pollLoop(ctx context.Context, clk clock.Clock, interval time.Duration, changed <-chan struct{}, poll func()) {
+	poll()
+
+	ticker := clk.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			poll()
+		case <-changed:
+			poll()
+		}
+	}
+}
+
+// sameInstance returns a function checking whether an endpoint belongs to the same service instance as the endpoint
+func sameInstance(endpoint types.ServiceEndpoint) func(types.ServiceEndpoint) bool {
+	return func(other types.ServiceEndpoint) bool {
+		return other.ServiceId == endpoint.ServiceId && other.InstanceId == endpoint.InstanceId
+	}
+}
+
+// subscribeChanges subscribes to the change notifications of the service, or of every service if the service key is
+// empty, returning the channel signalled when a change is notified. Notifications arriving while a poll is pending are
+// coalesced into that poll. The channel is never signalled if there is no notifier.
+func subscribeChanges(ctx context.Context, notifier types.RegistryChangeNotifier, serviceKey string) (<-chan struct{}, error) {
+	changed := make(chan struct{}, 1)
+	if notifier == nil {
+		return changed, nil
+	}
+
+	err := notifier.Subscribe(ctx, func(changedServiceId string) {
+		if serviceKey != "" && changedServiceId != "" && changedServiceId != serviceKey {
+			return
+		}
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to registry change notifications: %v", err)
+	}
+
+	return changed, nil
+}
+
+// pollStatus returns the status Keeper reports for the service, which is empty if the service isn't registered,
+// and whether the service is still within its health check grace period. False is returned when Keeper couldn't be reached.
+func (k *keeperClient) pollStatus(serviceKey string) (string, bool, bool) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	resp, err := k.registrationByServiceKey(serviceKey)
+	if err != nil {
+		if err.Code() == http.StatusNotFound {
+			return "", false, true
+		}
+		k.config.GetLogger().Warnf("Failed to poll the %s service status from Keeper: %v", serviceKey, err)
+		return "", false, false
+	}
+
+	if strings.EqualFold(resp.Registration.Status, models.Halt) {
+		return "", false, true
+	}
+
+	return resp.Registration.Status, k.inGracePeriod(resp.Registration), true
+}
@@ -0,0 +1,221 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+const watchTimeout = 5 * time.Second
+
+func TestSubscribeHealthEvents(t *testing.T) {
+	if mockKeeper == nil {
+		t.Skip("requires the mock Keeper to change the service status")
+	}
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.Clock = fakeClock
+
+	// Try to clean-up after test
+	defer func() {
+		_ = client.Unregister()
+	}()
+
+	setMockStatus(t, client.serviceKey, models.Up)
+
+	events := make(chan types.HealthEvent, 10)
+	unsubscribe, err := client.SubscribeHealthEvents(client.serviceKey, func(event types.HealthEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	// The ticker is only created once the initial health has been established
+	fakeClock.BlockUntil(1)
+	interval, _ := client.config.GetWatchInterval()
+
+	setMockStatus(t, client.serviceKey, models.Down)
+	fakeClock.Advance(interval)
+	event := receive(t, events)
+	require.Equal(t, client.serviceKey, event.ServiceId)
+	require.False(t, event.Healthy)
+	require.Equal(t, models.Down, event.Status)
+
+	setMockStatus(t, client.serviceKey, models.Up)
+	fakeClock.Advance(interval)
+	event = receive(t, events)
+	require.True(t, event.Healthy)
+
+	require.Empty(t, events, "only transitions should be reported")
+}
+
+type testChangeNotifier struct {
+	handlers chan func(serviceId string)
+}
+
+func (n *testChangeNotifier) Subscribe(_ context.Context, handler func(serviceId string)) error {
+	n.handlers <- handler
+	return nil
+}
+
+func TestSubscribeHealthEventsChangeNotifier(t *testing.T) {
+	if mockKeeper == nil {
+		t.Skip("requires the mock Keeper to change the service status")
+	}
+
+	notifier := &testChangeNotifier{handlers: make(chan func(serviceId string), 1)}
+	fakeClock := clock.NewFakeClock(time.Now())
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.Clock = fakeClock
+	client.config.ChangeNotifier = notifier
+
+	setMockStatus(t, client.serviceKey, models.Up)
+
+	events := make(chan types.HealthEvent, 10)
+	unsubscribe, err := client.SubscribeHealthEvents(client.serviceKey, func(event types.HealthEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	handler := <-notifier.handlers
+	fakeClock.BlockUntil(1)
+
+	setMockStatus(t, client.serviceKey, models.Down)
+	handler(client.serviceKey)
+
+	// Polled without the clock moving
+	event := receive(t, events)
+	require.False(t, event.Healthy)
+	require.Empty(t, events)
+}
+
+func TestSubscribeHealthEventsInvalidInterval(t *testing.T) {
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.WatchInterval = "bogus"
+
+	_, err := client.SubscribeHealthEvents(client.serviceKey, func(types.HealthEvent) {})
+	require.Error(t, err)
+}
+
+func setMockStatus(t *testing.T, serviceKey string, status string) {
+	if !mockKeeper.SetStatus(serviceKey, status) {
+		mockKeeper.SetRegistration(dtos.Registration{
+			ServiceId: serviceKey,
+			Host:      defaultServiceHost,
+			Port:      defaultServicePort,
+			Status:    status,
+		})
+	}
+}
+
+// receive returns the next value sent on the channel, failing the test if none is sent in time
+func receive[T any](t *testing.T, values <-chan T) T {
+	select {
+	case value := <-values:
+		return value
+	case <-time.After(watchTimeout):
+		require.Fail(t, "nothing received")
+		var zero T
+		return zero
+	}
+}
+
+func TestWatchAllServices(t *testing.T) {
+	if mockKeeper == nil {
+		t.Skip("requires the mock Keeper to change the registrations")
+	}
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.Clock = fakeClock
+
+	updates := make(chan []types.ServiceEndpoint, 10)
+	errs := make(chan error, 10)
+	unsubscribe, err := client.WatchAllServices(updates, errs)
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	initial := receive(t, updates)
+	fakeClock.BlockUntil(1)
+	interval, _ := client.config.GetWatchInterval()
+
+	setMockStatus(t, client.serviceKey, models.Up)
+	defer mockKeeper.RemoveRegistration(client.serviceKey)
+	fakeClock.Advance(interval)
+	update := receive(t, updates)
+	require.Len(t, update, len(initial)+1)
+	require.True(t, slices.ContainsFunc(update, func(endpoint types.ServiceEndpoint) bool {
+		return endpoint.ServiceId == client.serviceKey
+	}))
+
+	// Polls without changes aren't reported
+	fakeClock.Advance(interval)
+	require.Never(t, func() bool { return len(updates) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+
+	mockKeeper.SetErrorResponse(http.StatusServiceUnavailable)
+	defer mockKeeper.SetErrorResponse(0)
+	fakeClock.Advance(interval)
+	require.Error(t, receive(t, errs))
+	require.Empty(t, updates)
+}
+
+func TestSubscribeRegistrationEvents(t *testing.T) {
+	if mockKeeper == nil {
+		t.Skip("requires the mock Keeper to change the registrations")
+	}
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	client := makeKeeperClient(t, getUniqueServiceName(), defaultServiceHost, defaultServicePort, true)
+	client.config.Clock = fakeClock
+
+	events := make(chan types.RegistrationEvent, 10)
+	unsubscribe, err := client.SubscribeRegistrationEvents(func(event types.RegistrationEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	// The ticker is only created once the registered services have been established
+	fakeClock.BlockUntil(1)
+	interval, _ := client.config.GetWatchInterval()
+
+	setMockStatus(t, client.serviceKey, models.Up)
+	defer mockKeeper.RemoveRegistration(client.serviceKey)
+	fakeClock.Advance(interval)
+	event := receive(t, events)
+	require.True(t, event.Registered)
+	require.Equal(t, client.serviceKey, event.Endpoint.ServiceId)
+	require.Equal(t, defaultServicePort, event.Endpoint.Port)
+
+	// Status changes aren't reported, nor are polls failing to reach Keeper
+	setMockStatus(t, client.serviceKey, models.Down)
+	fakeClock.Advance(interval)
+	mockKeeper.SetErrorResponse(http.StatusServiceUnavailable)
+	fakeClock.Advance(interval)
+	require.Never(t, func() bool { return len(events) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+	mockKeeper.SetErrorResponse(0)
+
+	mockKeeper.RemoveRegistration(client.serviceKey)
+	fakeClock.Advance(interval)
+	event = receive(t, events)
+	require.False(t, event.Registered)
+	require.Equal(t, client.serviceKey, event.Endpoint.ServiceId)
+	require.Empty(t, events)
+}
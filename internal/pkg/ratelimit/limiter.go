@@ -0,0 +1,87 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+)
+
+// Limiter is a token bucket rate limiter. A nil Limiter doesn't limit.
+type Limiter struct {
+	lock   sync.Mutex
+	clock  clock.Clock
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter creates a Limiter allowing ratePerSecond requests on average with bursts of up to burst requests.
+// Nil is returned when ratePerSecond isn't positive, i.e. no limit. A burst less than 1 is treated as 1.
+func NewLimiter(ratePerSecond float64, burst int, clk clock.Clock) *Limiter {
+	if ratePerSecond <= 0 {
+		return nil
+	}
+
+	burstSize := float64(max(burst, 1))
+	return &Limiter{
+		clock:  clk,
+		rate:   ratePerSecond,
+		burst:  burstSize,
+		tokens: burstSize,
+		last:   clk.Now(),
+	}
+}
+
+// Wait blocks until a request is allowed or the context is done
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	wait := l.reserve()
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	case <-l.clock.After(wait):
+		return nil
+	}
+}
+
+// reserve takes a token, possibly going into debt, and returns how long the caller must wait for it
+func (l *Limiter) reserve() time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Now()
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	l.tokens = min(l.tokens+elapsed*l.rate, l.burst)
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns the token taken by a reservation which wasn't used
+func (l *Limiter) cancel() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.tokens = min(l.tokens+1, l.burst)
+}
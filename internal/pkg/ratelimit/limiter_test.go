@@ -0,0 +1,77 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+)
+
+func TestNewLimiterUnlimited(t *testing.T) {
+	limiter := NewLimiter(0, 5, clock.New())
+	require.Nil(t, limiter)
+	require.NoError(t, limiter.Wait(context.Background()))
+}
+
+func TestLimiterReserve(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	limiter := NewLimiter(2, 2, fakeClock)
+
+	// The burst is available immediately
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+
+	// Then requests are spaced by the rate
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+	assert.Equal(t, time.Second, limiter.reserve())
+
+	// Tokens are refilled over time, but never beyond the burst
+	fakeClock.Advance(10 * time.Second)
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+}
+
+func TestLimiterWait(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	limiter := NewLimiter(1, 1, fakeClock)
+
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- limiter.Wait(context.Background())
+	}()
+
+	fakeClock.BlockUntil(1)
+	select {
+	case <-done:
+		require.Fail(t, "Wait returned before a token was available")
+	default:
+	}
+
+	fakeClock.Advance(time.Second)
+	require.NoError(t, <-done)
+}
+
+func TestLimiterWaitCancelled(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	limiter := NewLimiter(1, 1, fakeClock)
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+
+	// The cancelled reservation is returned, so the next request only waits for the first one
+	assert.Equal(t, time.Second, limiter.reserve())
+}
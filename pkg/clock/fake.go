@@ -0,0 +1,134 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock whose time only moves when Advance is called. It is intended for unit tests.
+type FakeClock struct {
+	lock    sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	period   time.Duration
+	channel  chan time.Time
+}
+
+// NewFakeClock creates a FakeClock starting at the specified time
+func NewFakeClock(now time.Time) *FakeClock {
+	fake := &FakeClock{now: now}
+	fake.cond = sync.NewCond(&fake.lock)
+	return fake
+}
+
+func (f *FakeClock) Now() time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.now
+}
+
+func (f *FakeClock) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+func (f *FakeClock) After(d time.Duration) <-chan time.Time {
+	return f.addWaiter(d, 0).channel
+}
+
+func (f *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	return &fakeTicker{clock: f, waiter: f.addWaiter(d, d)}
+}
+
+// Advance moves the time forward by the duration, firing any timers and tickers that become due
+func (f *FakeClock) Advance(d time.Duration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.now = f.now.Add(d)
+
+	var pending []*fakeWaiter
+	for _, w := range f.waiters {
+		if w.deadline.After(f.now) {
+			pending = append(pending, w)
+			continue
+		}
+
+		// Same as the time package, ticks are dropped when the receiver falls behind
+		select {
+		case w.channel <- f.now:
+		default:
+		}
+
+		if w.period > 0 {
+			for !w.deadline.After(f.now) {
+				w.deadline = w.deadline.Add(w.period)
+			}
+			pending = append(pending, w)
+		}
+	}
+	f.waiters = pending
+}
+
+// BlockUntil blocks until at least the specified number of timers and tickers are waiting on the clock.
+// This allows tests to synchronize with goroutines before calling Advance.
+func (f *FakeClock) BlockUntil(waiters int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for len(f.waiters) < waiters {
+		f.cond.Wait()
+	}
+}
+
+func (f *FakeClock) addWaiter(d time.Duration, period time.Duration) *fakeWaiter {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	w := &fakeWaiter{
+		deadline: f.now.Add(d),
+		period:   period,
+		channel:  make(chan time.Time, 1),
+	}
+	f.waiters = append(f.waiters, w)
+	f.cond.Broadcast()
+
+	return w
+}
+
+func (f *FakeClock) removeWaiter(waiter *fakeWaiter) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for i, w := range f.waiters {
+		if w == waiter {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+type fakeTicker struct {
+	clock  *FakeClock
+	waiter *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.waiter.channel
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.removeWaiter(t.waiter)
+}
@@ -0,0 +1,86 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var startTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFakeClockAfter(t *testing.T) {
+	fake := NewFakeClock(startTime)
+
+	after := fake.After(10 * time.Second)
+
+	fake.Advance(9 * time.Second)
+	assertNotFired(t, after)
+
+	fake.Advance(time.Second)
+	fired := assertFired(t, after)
+	assert.Equal(t, startTime.Add(10*time.Second), fired)
+	assert.Equal(t, 10*time.Second, fake.Since(startTime))
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	fake := NewFakeClock(startTime)
+
+	ticker := fake.NewTicker(time.Second)
+
+	fake.Advance(time.Second)
+	assertFired(t, ticker.C())
+
+	fake.Advance(500 * time.Millisecond)
+	assertNotFired(t, ticker.C())
+
+	fake.Advance(500 * time.Millisecond)
+	assertFired(t, ticker.C())
+
+	ticker.Stop()
+	fake.Advance(time.Second)
+	assertNotFired(t, ticker.C())
+}
+
+func TestFakeClockBlockUntil(t *testing.T) {
+	fake := NewFakeClock(startTime)
+	done := make(chan struct{})
+
+	go func() {
+		<-fake.After(time.Minute)
+		close(done)
+	}()
+
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "goroutine waiting on fake clock never resumed")
+	}
+}
+
+func assertFired(t *testing.T, c <-chan time.Time) time.Time {
+	select {
+	case fired := <-c:
+		return fired
+	default:
+		require.Fail(t, "expected channel to have fired")
+		return time.Time{}
+	}
+}
+
+func assertNotFired(t *testing.T, c <-chan time.Time) {
+	select {
+	case <-c:
+		require.Fail(t, "expected channel to not have fired")
+	default:
+	}
+}
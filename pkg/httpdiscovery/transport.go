@@ -0,0 +1,174 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package httpdiscovery provides an http.RoundTripper which lets application code call peer services by their service
+// key, e.g. http://core-metadata/api/v3/ping, resolving the actual address through the registry.
+package httpdiscovery
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+const defaultCacheTTL = 30 * time.Second
+
+// Resolver resolves a service key to the endpoint of the service. Implemented by registry.Client.
+type Resolver interface {
+	GetServiceEndpoint(serviceKey string) (types.ServiceEndpoint, error)
+}
+
+// Options holds the optional settings of the Transport
+type Options struct {
+	// Base is the transport sending the rewritten requests. http.DefaultTransport is used if not set.
+	Base http.RoundTripper
+	// CacheTTL is how long resolved endpoints are cached. 30 seconds is used if not set.
+	CacheTTL time.Duration
+	// Clock is the source of time for the cache expiry. The system clock is used if not set.
+	Clock clock.Clock
+	// ServiceKeys limits the hosts resolved through the registry to these service keys, so requests to any other host
+	// never involve the registry. Every host without a port is resolved if not set.
+	ServiceKeys []string
+}
+
+type cacheEntry struct {
+	endpoint types.ServiceEndpoint
+	found    bool
+	expires  time.Time
+}
+
+// Transport rewrites requests addressed to a service key without a port, such as http://core-data/..., to the endpoint
+// of the service. Requests to hosts which aren't registered services are sent unchanged, as are the requests which
+// can't be resolved because the registry failed. When sending a request to a cached endpoint fails, the endpoint is
+// resolved again and the request retried once if the endpoint changed.
+type Transport struct {
+	resolver    Resolver
+	base        http.RoundTripper
+	cacheTTL    time.Duration
+	clock       clock.Clock
+	serviceKeys []string
+
+	lock  sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// NewTransport creates a Transport resolving service keys with the resolver
+func NewTransport(resolver Resolver, options Options) *Transport {
+	transport := &Transport{
+		resolver:    resolver,
+		base:        options.Base,
+		cacheTTL:    options.CacheTTL,
+		clock:       options.Clock,
+		serviceKeys: options.ServiceKeys,
+		cache:       make(map[string]cacheEntry),
+	}
+	if transport.base == nil {
+		transport.base = http.DefaultTransport
+	}
+	if transport.cacheTTL <= 0 {
+		transport.cacheTTL = defaultCacheTTL
+	}
+	if transport.clock == nil {
+		transport.clock = clock.New()
+	}
+
+	return transport
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Service keys never come with a port, so requests with one are sent as addressed
+	serviceKey := req.URL.Hostname()
+	if req.URL.Port() != "" || (len(t.serviceKeys) > 0 && !slices.Contains(t.serviceKeys, serviceKey)) {
+		return t.base.RoundTrip(req)
+	}
+
+	// The host may be resolved without the registry, e.g. by DNS, so the request is still sent if the registry failed
+	endpoint, found, cached, err := t.resolve(serviceKey)
+	if err != nil || !found {
+		return t.base.RoundTrip(req)
+	}
+
+	resp, err := t.base.RoundTrip(rewrite(req, endpoint))
+	if err == nil || !cached || !canRetry(req) {
+		return resp, err
+	}
+
+	// The cached endpoint may be stale, e.g. the service restarted on another host
+	t.Invalidate(serviceKey)
+	refreshed, found, _, resolveErr := t.resolve(serviceKey)
+	if resolveErr != nil || !found || refreshed.HostPort() == endpoint.HostPort() {
+		return resp, err
+	}
+
+	retryReq := req
+	if req.Body != nil && req.Body != http.NoBody {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, err
+		}
+		retryReq = req.Clone(req.Context())
+		retryReq.Body = body
+	}
+
+	return t.base.RoundTrip(rewrite(retryReq, refreshed))
+}
+
+// Invalidate removes the service from the cache, so it is resolved again on its next request
+func (t *Transport) Invalidate(serviceKey string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.cache, serviceKey)
+}
+
+// resolve returns the endpoint of the service, whether the service is registered and whether the result came from the cache
+func (t *Transport) resolve(serviceKey string) (types.ServiceEndpoint, bool, bool, error) {
+	t.lock.Lock()
+	entry, ok := t.cache[serviceKey]
+	t.lock.Unlock()
+	if ok && t.clock.Now().Before(entry.expires) {
+		return entry.endpoint, entry.found, true, nil
+	}
+
+	endpoint, err := t.resolver.GetServiceEndpoint(serviceKey)
+	found := true
+	if err != nil {
+		if !errors.Is(err, types.ErrServiceNotFound) {
+			return types.ServiceEndpoint{}, false, false, fmt.Errorf("failed to resolve service %s: %w", serviceKey, err)
+		}
+		found = false
+	}
+
+	t.lock.Lock()
+	t.cache[serviceKey] = cacheEntry{endpoint: endpoint, found: found, expires: t.clock.Now().Add(t.cacheTTL)}
+	t.lock.Unlock()
+
+	return endpoint, found, false, nil
+}
+
+// rewrite returns a copy of the request addressed to the endpoint
+func rewrite(req *http.Request, endpoint types.ServiceEndpoint) *http.Request {
+	rewritten := req.Clone(req.Context())
+	rewritten.URL.Host = endpoint.HostPort()
+	if endpoint.Protocol != "" {
+		rewritten.URL.Scheme = endpoint.Protocol
+	}
+	// Let the Host header follow the rewritten URL
+	rewritten.Host = ""
+
+	return rewritten
+}
+
+// canRetry returns true when the request can be sent again, i.e. it has no body or the body can be recreated
+func canRetry(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
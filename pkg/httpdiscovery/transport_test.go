@@ -0,0 +1,142 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package httpdiscovery
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+	"github.com/edgexfoundry/go-mod-registry/v4/registry/mocks"
+)
+
+func startService(t *testing.T, name string) (*httptest.Server, types.ServiceEndpoint) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		_, _ = fmt.Fprintf(writer, "%s %s %s", name, request.URL.Path, body)
+	}))
+
+	serverUrl, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverUrl.Port())
+	return server, types.ServiceEndpoint{ServiceId: "core-data", Host: serverUrl.Hostname(), Port: port}
+}
+
+func get(t *testing.T, client *http.Client, target string) string {
+	resp, err := client.Get(target)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestTransportResolvesServiceKey(t *testing.T) {
+	server, endpoint := startService(t, "core-data")
+	defer server.Close()
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	resolver := mocks.NewClient(t)
+	resolver.On("GetServiceEndpoint", "core-data").Return(endpoint, nil).Twice()
+	client := &http.Client{Transport: NewTransport(resolver, Options{CacheTTL: time.Minute, Clock: fakeClock})}
+
+	assert.Equal(t, "core-data /api/v3/ping ", get(t, client, "http://core-data/api/v3/ping"))
+
+	// Resolved from the cache until it expires
+	assert.Equal(t, "core-data /api/v3/ping ", get(t, client, "http://core-data/api/v3/ping"))
+	resolver.AssertNumberOfCalls(t, "GetServiceEndpoint", 1)
+
+	fakeClock.Advance(time.Minute)
+	assert.Equal(t, "core-data /api/v3/ping ", get(t, client, "http://core-data/api/v3/ping"))
+	resolver.AssertNumberOfCalls(t, "GetServiceEndpoint", 2)
+}
+
+type recordingTransport struct {
+	hosts []string
+}
+
+func (r *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.hosts = append(r.hosts, req.URL.Host)
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+func TestTransportUnresolvedHost(t *testing.T) {
+	resolver := mocks.NewClient(t)
+	resolver.On("GetServiceEndpoint", "example.com").Return(types.ServiceEndpoint{}, fmt.Errorf("lookup: %w", types.ErrServiceNotFound)).Once()
+	base := &recordingTransport{}
+	client := &http.Client{Transport: NewTransport(resolver, Options{Base: base})}
+
+	// Requests with a port are never resolved
+	_, err := client.Get("http://core-data:59880/api/v3/ping")
+	require.NoError(t, err)
+
+	// Hosts which aren't registered are called as addressed, with the result cached
+	for range 2 {
+		_, err = client.Get("http://example.com/")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"core-data:59880", "example.com", "example.com"}, base.hosts)
+
+	// Hosts which can't be resolved because the registry failed are also called as addressed, without caching the failure
+	resolver.On("GetServiceEndpoint", "support-notifications").Return(types.ServiceEndpoint{}, errors.New("registry unavailable")).Twice()
+	for range 2 {
+		_, err = client.Get("http://support-notifications/api/v3/ping")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "support-notifications", base.hosts[len(base.hosts)-1])
+}
+
+func TestTransportServiceKeys(t *testing.T) {
+	server, endpoint := startService(t, "core-data")
+	defer server.Close()
+
+	resolver := mocks.NewClient(t)
+	resolver.On("GetServiceEndpoint", "core-data").Return(endpoint, nil).Once()
+	base := &recordingTransport{}
+	client := &http.Client{Transport: NewTransport(resolver, Options{Base: base, ServiceKeys: []string{"core-data"}})}
+
+	// Only the listed service keys are resolved through the registry
+	_, err := client.Get("http://example.com/")
+	require.NoError(t, err)
+	_, err = client.Get("http://core-data/api/v3/ping")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com", endpoint.HostPort()}, base.hosts)
+}
+
+func TestTransportStaleEndpoint(t *testing.T) {
+	stale, staleEndpoint := startService(t, "stale")
+	stale.Close()
+	server, endpoint := startService(t, "current")
+	defer server.Close()
+
+	resolver := mocks.NewClient(t)
+	resolver.On("GetServiceEndpoint", "core-data").Return(staleEndpoint, nil).Once()
+	transport := NewTransport(resolver, Options{})
+	client := &http.Client{Transport: transport}
+
+	// Resolved directly rather than from the cache, so the failure is returned as is
+	_, err := client.Get("http://core-data/api/v3/ping")
+	require.Error(t, err)
+
+	resolver.On("GetServiceEndpoint", "core-data").Return(endpoint, nil).Once()
+	resp, err := client.Post("http://core-data/api/v3/event", "text/plain", strings.NewReader("reading"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "current /api/v3/event reading", string(body))
+}
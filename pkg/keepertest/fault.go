@@ -0,0 +1,89 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keepertest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fault describes a failure or delay injected into the requests it matches
+type Fault struct {
+	// Method is the method of the matching requests. Requests with any method match if not set.
+	Method string
+	// Path is part of the path of the matching requests, e.g. common.ApiRegisterRoute. Requests to any path match if not set.
+	Path string
+	// ErrorRate is the fraction, between 0 and 1, of the matching requests which fail. Failures are spread evenly rather
+	// than randomly, so with 0.5 every second matching request fails, keeping tests deterministic.
+	ErrorRate float64
+	// StatusCode is the status code of the failing requests. 500 is used if not set.
+	StatusCode int
+	// Latency delays every matching request, whether it fails or not
+	Latency time.Duration
+}
+
+// injectedFault tracks how many requests matched the fault, to spread the failures according to the error rate
+type injectedFault struct {
+	Fault
+	matched int
+}
+
+func (f *injectedFault) matches(request *http.Request) bool {
+	return (f.Method == "" || strings.EqualFold(f.Method, request.Method)) &&
+		(f.Path == "" || strings.Contains(request.URL.Path, f.Path))
+}
+
+// fail counts the request as matched and returns whether it must fail. The n-th matching request fails when
+// it brings the number of failures expected at the error rate to the next integer.
+func (f *injectedFault) fail() bool {
+	f.matched++
+	rate := min(max(f.ErrorRate, 0), 1)
+	return int(float64(f.matched)*rate) > int(float64(f.matched-1)*rate)
+}
+
+func (f *injectedFault) statusCode() int {
+	if f.StatusCode == 0 {
+		return http.StatusInternalServerError
+	}
+	return f.StatusCode
+}
+
+// InjectFault adds the fault to the requests it matches. Faults are applied in the order they were added, with the first
+// failing fault determining the response.
+func (mock *MockKeeper) InjectFault(fault Fault) {
+	mock.serviceLock.Lock()
+	defer mock.serviceLock.Unlock()
+
+	mock.faults = append(mock.faults, &injectedFault{Fault: fault})
+}
+
+// ClearFaults removes all injected faults
+func (mock *MockKeeper) ClearFaults() {
+	mock.serviceLock.Lock()
+	defer mock.serviceLock.Unlock()
+
+	mock.faults = nil
+}
+
+// applyFaults returns the total latency of the faults matching the request and the status code of the first one failing
+// it, if any. Must be called with the lock held.
+func (mock *MockKeeper) applyFaults(request *http.Request) (time.Duration, int) {
+	var latency time.Duration
+	statusCode := 0
+	for _, fault := range mock.faults {
+		if !fault.matches(request) {
+			continue
+		}
+
+		latency += fault.Latency
+		if fault.fail() && statusCode == 0 {
+			statusCode = fault.statusCode()
+		}
+	}
+
+	return latency, statusCode
+}
@@ -0,0 +1,60 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keepertest_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/keepertest"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+func TestMockKeeperFaultErrorRate(t *testing.T) {
+	mock := keepertest.NewMockKeeper()
+	client := newClient(t, mock)
+
+	mock.InjectFault(keepertest.Fault{Method: http.MethodGet, Path: common.ApiPingRoute, ErrorRate: 0.5})
+
+	var results []bool
+	for range 4 {
+		results = append(results, client.IsAlive())
+	}
+	assert.Equal(t, []bool{true, false, true, false}, results)
+
+	mock.ClearFaults()
+	assert.True(t, client.IsAlive())
+}
+
+func TestMockKeeperFaultStatusCode(t *testing.T) {
+	mock := keepertest.NewMockKeeper()
+	client := newClient(t, mock)
+
+	// Only registration requests fail
+	mock.InjectFault(keepertest.Fault{Path: common.ApiRegisterRoute, ErrorRate: 1, StatusCode: http.StatusForbidden})
+
+	assert.True(t, client.IsAlive())
+	err := client.Register()
+	assert.ErrorIs(t, err, types.ErrAccessDenied)
+}
+
+func TestMockKeeperFaultLatency(t *testing.T) {
+	const latency = 100 * time.Millisecond
+	mock := keepertest.NewMockKeeper()
+	client := newClient(t, mock)
+
+	mock.InjectFault(keepertest.Fault{Path: common.ApiPingRoute, Latency: latency})
+
+	start := time.Now()
+	require.True(t, client.IsAlive(), "latency only faults should not fail the request")
+	assert.GreaterOrEqual(t, time.Since(start), latency)
+}
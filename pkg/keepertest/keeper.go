@@ -0,0 +1,314 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package keepertest provides a mock Core Keeper server implementing the registry APIs, so services using the registry
+// client can be unit tested against Keeper semantics without running Core Keeper.
+package keepertest
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+)
+
+// ApiRegistrationByServiceIdRoute is the prefix of the route serving a single registration, followed by the service ID
+const ApiRegistrationByServiceIdRoute = common.ApiRegisterRoute + "/" + common.ServiceId + "/"
+
+// Version is the version reported by the mock Keeper
+const Version = "0.0.0-mock"
+
+// HealthChecker determines the status of a service when it registers
+type HealthChecker func(registration dtos.Registration) string
+
+// MockKeeper simulates the registry APIs of Core Keeper, keeping the registrations in memory
+type MockKeeper struct {
+	serviceStore  map[string]dtos.Registration
+	serviceLock   sync.Mutex
+	healthChecker HealthChecker
+	errorCode     int
+	faults        []*injectedFault
+}
+
+// NewMockKeeper creates a MockKeeper without registrations, which checks the health of registering services by calling
+// their health check route once
+func NewMockKeeper() *MockKeeper {
+	mock := MockKeeper{
+		serviceStore: make(map[string]dtos.Registration),
+	}
+
+	return &mock
+}
+
+// Start starts the mock Keeper listening on a local HTTP port
+func (mock *MockKeeper) Start() *httptest.Server {
+	return httptest.NewServer(mock.Handler())
+}
+
+// StartTLS starts the mock Keeper listening on a local HTTPS port, using a self-signed certificate
+func (mock *MockKeeper) StartTLS() *httptest.Server {
+	return httptest.NewTLSServer(mock.Handler())
+}
+
+// StartUnix starts the mock Keeper listening on the unix domain socket at the specified path
+func (mock *MockKeeper) StartUnix(socketPath string) (*httptest.Server, error) {
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+
+	server := httptest.NewUnstartedServer(mock.Handler())
+	server.Listener = listener
+	server.Start()
+	return server, nil
+}
+
+// Registration returns the registration of the service, if any
+func (mock *MockKeeper) Registration(serviceId string) (dtos.Registration, bool) {
+	mock.serviceLock.Lock()
+	defer mock.serviceLock.Unlock()
+
+	registration, ok := mock.serviceStore[serviceId]
+	return registration, ok
+}
+
+// Registrations returns all the registrations, including the de-registered ones
+func (mock *MockKeeper) Registrations() []dtos.Registration {
+	mock.serviceLock.Lock()
+	defer mock.serviceLock.Unlock()
+
+	registrations := make([]dtos.Registration, 0, len(mock.serviceStore))
+	for _, r := range mock.serviceStore {
+		registrations = append(registrations, r)
+	}
+	return registrations
+}
+
+// SetRegistration adds or replaces the registration of the service as is, setting its creation time if not set
+func (mock *MockKeeper) SetRegistration(registration dtos.Registration) {
+	mock.serviceLock.Lock()
+	defer mock.serviceLock.Unlock()
+
+	if registration.Created == 0 {
+		registration.Created = time.Now().UnixMilli()
+	}
+	mock.serviceStore[registration.ServiceId] = registration
+}
+
+// SetStatus changes the status of the registered service, as Keeper does when the health check result changes.
+// False is returned if the service isn't registered.
+func (mock *MockKeeper) SetStatus(serviceId string, status string) bool {
+	mock.serviceLock.Lock()
+	defer mock.serviceLock.Unlock()
+
+	registration, ok := mock.serviceStore[serviceId]
+	if !ok {
+		return false
+	}
+
+	registration.Status = status
+	registration.Modified = time.Now().UnixMilli()
+	mock.serviceStore[serviceId] = registration
+	return true
+}
+
+// RemoveRegistration removes the registration of the service
+func (mock *MockKeeper) RemoveRegistration(serviceId string) {
+	mock.serviceLock.Lock()
+	defer mock.serviceLock.Unlock()
+
+	delete(mock.serviceStore, serviceId)
+}
+
+// SetHealthChecker replaces how the status of registering services is determined, e.g. to report services as healthy
+// without them serving their health check route. Nil restores the default of calling the health check route.
+func (mock *MockKeeper) SetHealthChecker(healthChecker HealthChecker) {
+	mock.serviceLock.Lock()
+	defer mock.serviceLock.Unlock()
+
+	mock.healthChecker = healthChecker
+}
+
+// SetErrorResponse makes every request fail with the status code, e.g. http.StatusServiceUnavailable.
+// Zero restores the normal responses.
+func (mock *MockKeeper) SetErrorResponse(statusCode int) {
+	mock.serviceLock.Lock()
+	defer mock.serviceLock.Unlock()
+
+	mock.errorCode = statusCode
+}
+
+// Reset removes all registrations and restores the default behavior
+func (mock *MockKeeper) Reset() {
+	mock.serviceLock.Lock()
+	defer mock.serviceLock.Unlock()
+
+	mock.serviceStore = make(map[string]dtos.Registration)
+	mock.healthChecker = nil
+	mock.errorCode = 0
+	mock.faults = nil
+}
+
+// Handler returns the HTTP handler serving the mock Keeper APIs, for use with a custom server
+func (mock *MockKeeper) Handler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mock.serviceLock.Lock()
+		latency, errorCode := mock.applyFaults(request)
+		if mock.errorCode != 0 {
+			errorCode = mock.errorCode
+		}
+		mock.serviceLock.Unlock()
+
+		// Delay without holding the lock so other requests aren't held up
+		time.Sleep(latency)
+
+		if errorCode != 0 {
+			writeResponse(writer, errorCode, dtoCommon.NewBaseResponse("", "injected error", errorCode))
+			return
+		}
+
+		mock.serviceLock.Lock()
+		defer mock.serviceLock.Unlock()
+
+		switch {
+		case strings.HasSuffix(request.URL.Path, common.ApiRegisterRoute):
+			mock.handleRegister(writer, request)
+		case strings.HasSuffix(request.URL.Path, common.ApiAllRegistrationsRoute):
+			if request.Method == http.MethodGet {
+				mock.handleAllRegistrations(writer, request)
+			}
+		case strings.Contains(request.URL.Path, ApiRegistrationByServiceIdRoute):
+			mock.handleRegistrationByServiceId(writer, request)
+		case strings.Contains(request.URL.Path, common.ApiVersionRoute):
+			if request.Method == http.MethodGet {
+				writeResponse(writer, http.StatusOK, dtoCommon.VersionResponse{
+					Versionable: dtoCommon.Versionable{ApiVersion: common.ApiVersion},
+					Version:     Version,
+					ServiceName: common.CoreKeeperServiceKey,
+				})
+			}
+		case strings.Contains(request.URL.Path, common.ApiPingRoute):
+			if request.Method == http.MethodGet {
+				writeResponse(writer, http.StatusOK, dtoCommon.PingResponse{
+					Versionable: dtoCommon.Versionable{ApiVersion: common.ApiVersion},
+				})
+			}
+		}
+	})
+}
+
+func (mock *MockKeeper) handleRegister(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost && request.Method != http.MethodPut {
+		return
+	}
+
+	bodyBytes, err := io.ReadAll(request.Body)
+	if err != nil {
+		log.Printf("error reading request body: %s", err.Error())
+	}
+
+	var req requests.AddRegistrationRequest
+	err = json.Unmarshal(bodyBytes, &req)
+	if err != nil {
+		log.Printf("error decoding request body: %s", err.Error())
+	}
+
+	existing, exists := mock.serviceStore[req.Registration.ServiceId]
+	now := time.Now().UnixMilli()
+	req.Registration.Created = now
+	if exists {
+		req.Registration.Created = existing.Created
+		req.Registration.Modified = now
+	}
+
+	if request.Method == http.MethodPut {
+		mock.serviceStore[req.Registration.ServiceId] = req.Registration
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	req.Registration.Status = mock.checkHealth(req.Registration)
+	mock.serviceStore[req.Registration.ServiceId] = req.Registration
+
+	writer.Header().Set(common.ContentTypeJSON, common.ContentTypeJSON)
+	writer.WriteHeader(http.StatusCreated)
+}
+
+func (mock *MockKeeper) checkHealth(registration dtos.Registration) string {
+	if mock.healthChecker != nil {
+		return mock.healthChecker(registration)
+	}
+
+	resp, err := http.Get(registration.HealthCheck.Type + "://" + net.JoinHostPort(registration.Host, strconv.Itoa(registration.Port)) + registration.HealthCheck.Path)
+	if err != nil {
+		log.Printf("error health checking: %s", err.Error())
+		return ""
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return models.Up
+	}
+	return models.Down
+}
+
+func (mock *MockKeeper) handleAllRegistrations(writer http.ResponseWriter, request *http.Request) {
+	deregistered, _ := strconv.ParseBool(request.URL.Query().Get(common.Deregistered))
+
+	var registrations []dtos.Registration
+	for _, r := range mock.serviceStore {
+		if !deregistered && strings.EqualFold(r.Status, models.Halt) {
+			continue
+		}
+		registrations = append(registrations, r)
+	}
+
+	writeResponse(writer, http.StatusOK, responses.MultiRegistrationsResponse{
+		BaseWithTotalCountResponse: dtoCommon.NewBaseWithTotalCountResponse("", "", http.StatusOK, uint32(len(registrations))),
+		Registrations:              registrations,
+	})
+}
+
+func (mock *MockKeeper) handleRegistrationByServiceId(writer http.ResponseWriter, request *http.Request) {
+	key := strings.Replace(request.URL.Path, ApiRegistrationByServiceIdRoute, "", 1)
+	switch request.Method {
+	case http.MethodGet:
+		r, ok := mock.serviceStore[key]
+		if !ok {
+			writeResponse(writer, http.StatusNotFound, dtoCommon.NewBaseResponse("", "not found", http.StatusNotFound))
+			return
+		}
+
+		writeResponse(writer, http.StatusOK, responses.RegistrationResponse{
+			BaseResponse: dtoCommon.NewBaseResponse("", "", http.StatusOK),
+			Registration: r,
+		})
+	case http.MethodDelete:
+		delete(mock.serviceStore, key)
+		writer.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeResponse(writer http.ResponseWriter, statusCode int, resp any) {
+	jsonData, _ := json.Marshal(resp)
+	writer.Header().Set(common.ContentType, common.ContentTypeJSON)
+	writer.WriteHeader(statusCode)
+	if _, err := writer.Write(jsonData); err != nil {
+		log.Printf("error writing data response: %s", err.Error())
+	}
+}
@@ -0,0 +1,90 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keepertest_test
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/keepertest"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+	"github.com/edgexfoundry/go-mod-registry/v4/registry"
+)
+
+const serviceKey = "core-data"
+
+func newClient(t *testing.T, mock *keepertest.MockKeeper) registry.Client {
+	server := mock.Start()
+	t.Cleanup(server.Close)
+
+	serverUrl, _ := url.Parse(server.URL)
+	serverPort, _ := strconv.Atoi(serverUrl.Port())
+
+	client, err := registry.NewRegistryClient(types.Config{
+		Host:          serverUrl.Hostname(),
+		Port:          serverPort,
+		Type:          "keeper",
+		ServiceKey:    serviceKey,
+		ServiceHost:   "localhost",
+		ServicePort:   59880,
+		CheckRoute:    common.ApiPingRoute,
+		CheckInterval: "10s",
+	})
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestMockKeeperRegistration(t *testing.T) {
+	mock := keepertest.NewMockKeeper()
+	mock.SetHealthChecker(func(dtos.Registration) string { return models.Up })
+	client := newClient(t, mock)
+
+	require.NoError(t, client.Register())
+	registration, ok := mock.Registration(serviceKey)
+	require.True(t, ok)
+	assert.Equal(t, models.Up, registration.Status)
+	assert.NotZero(t, registration.Created)
+
+	available, err := client.IsServiceAvailable(serviceKey)
+	require.NoError(t, err)
+	assert.True(t, available)
+
+	require.True(t, mock.SetStatus(serviceKey, models.Down))
+	_, err = client.IsServiceAvailable(serviceKey)
+	assert.ErrorIs(t, err, types.ErrServiceNotHealthy)
+
+	require.NoError(t, client.Unregister())
+	endpoints, err := client.GetAllServiceEndpoints()
+	require.NoError(t, err)
+	assert.Empty(t, endpoints, "de-registered services should be left out")
+	assert.Len(t, mock.Registrations(), 1)
+
+	mock.Reset()
+	assert.Empty(t, mock.Registrations())
+}
+
+func TestMockKeeperErrorResponse(t *testing.T) {
+	mock := keepertest.NewMockKeeper()
+	client := newClient(t, mock)
+
+	mock.SetErrorResponse(http.StatusForbidden)
+	err := client.Register()
+	assert.ErrorIs(t, err, types.ErrAccessDenied)
+	assert.False(t, client.IsAlive())
+
+	mock.SetErrorResponse(0)
+	assert.True(t, client.IsAlive())
+}
@@ -0,0 +1,188 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package resilient provides a decorator for any registry.Client which layers retries, caching and a last-known-good
+// fallback over the discovery calls, so consuming services get that behavior by wrapping their client.
+package resilient
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/retry"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+	"github.com/edgexfoundry/go-mod-registry/v4/registry"
+)
+
+const (
+	defaultCacheTTL = 30 * time.Second
+
+	// The cache keys of the services are prefixed so they can't clash with the key of all the endpoints
+	serviceCacheKeyPrefix = "service/"
+	allServicesCacheKey   = "all"
+)
+
+// Options holds the optional settings of the Client
+type Options struct {
+	// RetryPolicy is the retry policy applied to the discovery calls failing for another reason than the ones reported
+	// with the registry sentinel errors, e.g. because the Registry is unreachable. Calls are not retried if not set.
+	RetryPolicy retry.Policy
+	// CacheTTL is how long the discovered endpoints are served from the cache. 30 seconds is used if not set, while
+	// a negative value disables the cache.
+	CacheTTL time.Duration
+	// MaxStaleness is how old the last known good endpoints may be to be returned instead of the error when the
+	// discovery fails for another reason than the ones reported with the registry sentinel errors, e.g. because the
+	// Registry is unreachable. The last known good endpoints are returned whatever their age if not set.
+	MaxStaleness time.Duration
+	// DisableFallback turns off returning the last known good endpoints when the discovery fails
+	DisableFallback bool
+	// OnFallback is called with the operation and the error whenever the last known good endpoints are returned instead
+	OnFallback func(operation string, err error)
+	// Clock is the source of time for the retries and the cache expiry. The system clock is used if not set.
+	Clock clock.Clock
+}
+
+type cacheEntry struct {
+	value   any
+	fetched time.Time
+}
+
+// Client decorates a registry.Client. GetServiceEndpoint and GetAllServiceEndpoints are retried, cached and fall back
+// to the last known good endpoints as configured, while IsServiceAvailable is only retried, as a stale availability
+// would be misleading. All other calls go to the decorated client as is.
+type Client struct {
+	registry.Client
+	options Options
+
+	lock  sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// New creates a Client decorating the client
+func New(client registry.Client, options Options) *Client {
+	if options.CacheTTL == 0 {
+		options.CacheTTL = defaultCacheTTL
+	}
+	if options.Clock == nil {
+		options.Clock = clock.New()
+	}
+
+	return &Client{
+		Client:  client,
+		options: options,
+		cache:   make(map[string]cacheEntry),
+	}
+}
+
+// GetServiceEndpoint gets the endpoint of the service from the cache, or else from the decorated client
+func (c *Client) GetServiceEndpoint(serviceId string) (types.ServiceEndpoint, error) {
+	return call(c, "GetServiceEndpoint", serviceCacheKeyPrefix+serviceId, func() (types.ServiceEndpoint, error) {
+		return c.Client.GetServiceEndpoint(serviceId)
+	})
+}
+
+// GetAllServiceEndpoints gets all the endpoints from the cache, or else from the decorated client. Partial results
+// are returned as is, without being cached.
+func (c *Client) GetAllServiceEndpoints() ([]types.ServiceEndpoint, error) {
+	endpoints, err := call(c, "GetAllServiceEndpoints", allServicesCacheKey, c.Client.GetAllServiceEndpoints)
+	// The cached slice must not be modified by the caller
+	return slices.Clone(endpoints), err
+}
+
+// IsServiceAvailable checks with the decorated client if the service is available, retrying as configured
+func (c *Client) IsServiceAvailable(serviceId string) (bool, error) {
+	var available bool
+	err := c.retry(func() error {
+		var err error
+		available, err = c.Client.IsServiceAvailable(serviceId)
+		return err
+	})
+	return available, err
+}
+
+// Reconfigure reconfigures the decorated client, clearing the cache as the endpoints may come from another Registry
+func (c *Client) Reconfigure(registryConfig types.Config) error {
+	err := c.Client.Reconfigure(registryConfig)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	clear(c.cache)
+	return err
+}
+
+// Invalidate removes the service, along with the list of all endpoints, from the cache so they are discovered again.
+// The last known good endpoints are removed too.
+func (c *Client) Invalidate(serviceId string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.cache, serviceCacheKeyPrefix+serviceId)
+	delete(c.cache, allServicesCacheKey)
+}
+
+func call[T any](c *Client, operation string, cacheKey string, fn func() (T, error)) (T, error) {
+	now := c.options.Clock.Now()
+	c.lock.Lock()
+	entry, cached := c.cache[cacheKey]
+	c.lock.Unlock()
+
+	if cached && c.options.CacheTTL > 0 && now.Sub(entry.fetched) < c.options.CacheTTL {
+		return entry.value.(T), nil
+	}
+
+	var result T
+	err := c.retry(func() error {
+		var err error
+		result, err = fn()
+		return err
+	})
+	if err == nil {
+		c.lock.Lock()
+		c.cache[cacheKey] = cacheEntry{value: result, fetched: c.options.Clock.Now()}
+		c.lock.Unlock()
+		return result, nil
+	}
+
+	fallback := cached && !c.options.DisableFallback && !isRegistryError(err) &&
+		(c.options.MaxStaleness <= 0 || now.Sub(entry.fetched) <= c.options.MaxStaleness)
+	if !fallback {
+		return result, err
+	}
+
+	if c.options.OnFallback != nil {
+		c.options.OnFallback(operation, err)
+	}
+	return entry.value.(T), nil
+}
+
+// retry calls fn as configured by the RetryPolicy, only retrying the errors which aren't registry errors
+func (c *Client) retry(fn func() error) error {
+	return retry.Do(context.Background(), c.options.RetryPolicy, c.options.Clock, func(ctx context.Context) error {
+		err := fn()
+		if err != nil && isRegistryError(err) {
+			return retry.Permanent(err)
+		}
+		return err
+	})
+}
+
+// isRegistryError returns true when the Registry answered with a definitive result, reported with one of the registry
+// sentinel errors or a partial result, which retrying or falling back would hide
+func isRegistryError(err error) bool {
+	var partialErr *types.PartialResultError
+	return errors.As(err, &partialErr) ||
+		errors.Is(err, types.ErrServiceNotFound) ||
+		errors.Is(err, types.ErrNotRegistered) ||
+		errors.Is(err, types.ErrServiceNotHealthy) ||
+		errors.Is(err, types.ErrServiceStarting) ||
+		errors.Is(err, types.ErrAccessDenied) ||
+		errors.Is(err, types.ErrInvalidServiceKey) ||
+		errors.Is(err, types.ErrInvalidRegistration) ||
+		errors.Is(err, types.ErrUnsupported)
+}
@@ -0,0 +1,159 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resilient
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/retry"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+	"github.com/edgexfoundry/go-mod-registry/v4/registry"
+	"github.com/edgexfoundry/go-mod-registry/v4/registry/mocks"
+)
+
+var _ registry.Client = (*Client)(nil)
+
+var (
+	coreData       = types.ServiceEndpoint{ServiceId: "core-data", Host: "edgex-core-data", Port: 59880}
+	errUnreachable = errors.New("connection refused")
+)
+
+func TestCache(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	decorated := mocks.NewClient(t)
+	decorated.On("GetServiceEndpoint", "core-data").Return(coreData, nil).Twice()
+	decorated.On("GetAllServiceEndpoints").Return([]types.ServiceEndpoint{coreData}, nil).Once()
+
+	client := New(decorated, Options{CacheTTL: time.Minute, Clock: fakeClock})
+
+	for i := 0; i < 2; i++ {
+		endpoint, err := client.GetServiceEndpoint("core-data")
+		require.NoError(t, err)
+		assert.Equal(t, coreData, endpoint)
+
+		endpoints, err := client.GetAllServiceEndpoints()
+		require.NoError(t, err)
+		assert.Equal(t, []types.ServiceEndpoint{coreData}, endpoints)
+	}
+
+	// Expired entries are discovered again
+	fakeClock.Advance(time.Minute)
+	_, err := client.GetServiceEndpoint("core-data")
+	require.NoError(t, err)
+}
+
+func TestFallback(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		age            time.Duration
+		options        Options
+		expectFallback bool
+	}{
+		{"Registry unreachable", errUnreachable, time.Hour, Options{}, true},
+		{"Within max staleness", errUnreachable, time.Hour, Options{MaxStaleness: 2 * time.Hour}, true},
+		{"Beyond max staleness", errUnreachable, time.Hour, Options{MaxStaleness: 30 * time.Minute}, false},
+		{"Fallback disabled", errUnreachable, time.Hour, Options{DisableFallback: true}, false},
+		{"Service not found", fmt.Errorf("failed to get service core-data endpoint: %w", types.ErrServiceNotFound), time.Hour, Options{}, false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			fakeClock := clock.NewFakeClock(time.Now())
+			decorated := mocks.NewClient(t)
+			decorated.On("GetServiceEndpoint", "core-data").Return(coreData, nil).Once()
+			decorated.On("GetServiceEndpoint", "core-data").Return(types.ServiceEndpoint{}, testCase.err).Once()
+
+			var fallbacks []string
+			options := testCase.options
+			options.Clock = fakeClock
+			options.OnFallback = func(operation string, err error) {
+				assert.ErrorIs(t, err, testCase.err)
+				fallbacks = append(fallbacks, operation)
+			}
+			client := New(decorated, options)
+
+			_, err := client.GetServiceEndpoint("core-data")
+			require.NoError(t, err)
+
+			fakeClock.Advance(testCase.age)
+			endpoint, err := client.GetServiceEndpoint("core-data")
+			if !testCase.expectFallback {
+				require.ErrorIs(t, err, testCase.err)
+				assert.Empty(t, fallbacks)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, coreData, endpoint)
+			assert.Equal(t, []string{"GetServiceEndpoint"}, fallbacks)
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	policy := retry.Policy{MaxAttempts: 3, InitialInterval: time.Millisecond}
+
+	decorated := mocks.NewClient(t)
+	decorated.On("GetServiceEndpoint", "core-data").Return(types.ServiceEndpoint{}, errUnreachable).Once()
+	decorated.On("GetServiceEndpoint", "core-data").Return(coreData, nil).Once()
+	decorated.On("IsServiceAvailable", "core-data").Return(false, errUnreachable).Once()
+	decorated.On("IsServiceAvailable", "core-data").Return(true, nil).Once()
+	decorated.On("IsServiceAvailable", "core-metadata").Return(false, fmt.Errorf("not healthy: %w", types.ErrServiceNotHealthy)).Once()
+
+	client := New(decorated, Options{RetryPolicy: policy, CacheTTL: -1})
+
+	endpoint, err := client.GetServiceEndpoint("core-data")
+	require.NoError(t, err)
+	assert.Equal(t, coreData, endpoint)
+
+	available, err := client.IsServiceAvailable("core-data")
+	require.NoError(t, err)
+	assert.True(t, available)
+
+	// Definitive results from the Registry aren't retried
+	available, err = client.IsServiceAvailable("core-metadata")
+	require.ErrorIs(t, err, types.ErrServiceNotHealthy)
+	assert.False(t, available)
+}
+
+func TestPartialResult(t *testing.T) {
+	partialErr := &types.PartialResultError{Missing: 1}
+	decorated := mocks.NewClient(t)
+	decorated.On("GetAllServiceEndpoints").Return([]types.ServiceEndpoint{coreData}, partialErr).Twice()
+
+	client := New(decorated, Options{})
+
+	// Partial results aren't cached
+	for i := 0; i < 2; i++ {
+		endpoints, err := client.GetAllServiceEndpoints()
+		require.ErrorAs(t, err, &partialErr)
+		assert.Equal(t, []types.ServiceEndpoint{coreData}, endpoints)
+	}
+}
+
+func TestInvalidateAndReconfigure(t *testing.T) {
+	decorated := mocks.NewClient(t)
+	decorated.On("GetServiceEndpoint", "core-data").Return(coreData, nil).Times(3)
+	decorated.On("Reconfigure", types.Config{}).Return(nil).Once()
+
+	client := New(decorated, Options{})
+
+	_, err := client.GetServiceEndpoint("core-data")
+	require.NoError(t, err)
+	client.Invalidate("core-data")
+	_, err = client.GetServiceEndpoint("core-data")
+	require.NoError(t, err)
+	require.NoError(t, client.Reconfigure(types.Config{}))
+	_, err = client.GetServiceEndpoint("core-data")
+	require.NoError(t, err)
+}
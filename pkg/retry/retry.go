@@ -0,0 +1,134 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+)
+
+const (
+	defaultMultiplier = 2.0
+)
+
+// Policy defines how many times and how often an operation is retried using exponential backoff
+type Policy struct {
+	// MaxAttempts is the maximum number of attempts including the first one. Values less than 1 are treated as 1, i.e. no retries.
+	MaxAttempts int
+	// InitialInterval is the wait before the first retry
+	InitialInterval time.Duration
+	// MaxInterval caps the wait between retries. The wait is not capped if zero.
+	MaxInterval time.Duration
+	// Multiplier is the factor the wait grows by after each retry. 2 is used if less than 1.
+	Multiplier float64
+	// Jitter is the fraction, between 0 and 1, by which each wait is randomly shortened or lengthened
+	Jitter float64
+}
+
+// NotifyFunc is called before waiting for the next attempt with the error of the failed attempt,
+// the number of the failed attempt and the wait before the next one
+type NotifyFunc func(err error, attempt int, wait time.Duration)
+
+// DefaultPolicy returns the Policy used when retries are enabled without further tuning
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:     5,
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     10 * time.Second,
+		Multiplier:      defaultMultiplier,
+		Jitter:          0.2,
+	}
+}
+
+// Backoff returns the wait before the specified retry, where 1 is the first retry
+func (p Policy) Backoff(retry int) time.Duration {
+	if retry < 1 || p.InitialInterval <= 0 {
+		return 0
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = defaultMultiplier
+	}
+
+	wait := float64(p.InitialInterval) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxInterval > 0 && wait > float64(p.MaxInterval) {
+		wait = float64(p.MaxInterval)
+	}
+
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 {
+		delta := jitter * wait
+		wait = wait - delta + rand.Float64()*2*delta // #nosec G404 -- jitter does not require a secure random source
+	}
+
+	return time.Duration(wait)
+}
+
+// Do calls fn until it succeeds, it returns a permanent error, the attempts are exhausted or the context is done.
+// The error of the last attempt is returned on failure. The system clock is used if clk is nil.
+func Do(ctx context.Context, policy Policy, clk clock.Clock, fn func(ctx context.Context) error) error {
+	return DoNotify(ctx, policy, clk, fn, nil)
+}
+
+// DoNotify is the same as Do but also calls notify, if not nil, after each failed attempt that will be retried
+func DoNotify(ctx context.Context, policy Policy, clk clock.Clock, fn func(ctx context.Context) error, notify NotifyFunc) error {
+	if clk == nil {
+		clk = clock.New()
+	}
+
+	maxAttempts := max(policy.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+
+		if attempt >= maxAttempts {
+			return err
+		}
+
+		wait := policy.Backoff(attempt)
+		if notify != nil {
+			notify(err, attempt, wait)
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-clk.After(wait):
+		}
+	}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent wraps the error so that Do stops retrying and returns the wrapped error
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
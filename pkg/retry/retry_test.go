@@ -0,0 +1,150 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+)
+
+var errTest = errors.New("test error")
+
+func TestBackoff(t *testing.T) {
+	policy := Policy{
+		InitialInterval: time.Second,
+		MaxInterval:     5 * time.Second,
+		Multiplier:      2,
+	}
+
+	tests := []struct {
+		retry    int
+		expected time.Duration
+	}{
+		{0, 0},
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second},
+		{10, 5 * time.Second},
+	}
+
+	for _, testCase := range tests {
+		assert.Equal(t, testCase.expected, policy.Backoff(testCase.retry), "retry %d", testCase.retry)
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	policy := Policy{
+		InitialInterval: time.Second,
+		Jitter:          0.5,
+	}
+
+	for i := 0; i < 100; i++ {
+		wait := policy.Backoff(1)
+		assert.GreaterOrEqual(t, wait, 500*time.Millisecond)
+		assert.LessOrEqual(t, wait, 1500*time.Millisecond)
+	}
+}
+
+func TestDo(t *testing.T) {
+	policy := Policy{MaxAttempts: 3, InitialInterval: time.Second}
+
+	tests := []struct {
+		name             string
+		failures         int
+		err              error
+		expectedAttempts int
+		expectError      bool
+	}{
+		{"Succeeds first attempt", 0, errTest, 1, false},
+		{"Succeeds after retries", 2, errTest, 3, false},
+		{"Attempts exhausted", 5, errTest, 3, true},
+		{"Permanent error", 5, Permanent(errTest), 1, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			fakeClock := clock.NewFakeClock(time.Now())
+			attempts := 0
+			result := make(chan error, 1)
+
+			go func() {
+				result <- Do(context.Background(), policy, fakeClock, func(_ context.Context) error {
+					attempts++
+					if attempts <= testCase.failures {
+						return testCase.err
+					}
+					return nil
+				})
+			}()
+
+			err := waitForResult(fakeClock, result, policy.MaxInterval+8*time.Second)
+			assert.Equal(t, testCase.expectedAttempts, attempts)
+			if testCase.expectError {
+				require.ErrorIs(t, err, errTest)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDoNotify(t *testing.T) {
+	policy := Policy{MaxAttempts: 3, InitialInterval: time.Second, Multiplier: 2}
+	fakeClock := clock.NewFakeClock(time.Now())
+	var waits []time.Duration
+	result := make(chan error, 1)
+
+	go func() {
+		result <- DoNotify(context.Background(), policy, fakeClock,
+			func(_ context.Context) error {
+				return errTest
+			},
+			func(err error, attempt int, wait time.Duration) {
+				assert.ErrorIs(t, err, errTest)
+				assert.Equal(t, len(waits)+1, attempt)
+				waits = append(waits, wait)
+			})
+	}()
+
+	err := waitForResult(fakeClock, result, 3*time.Second)
+	require.ErrorIs(t, err, errTest)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, waits)
+}
+
+func TestDoContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+
+	err := Do(ctx, Policy{MaxAttempts: 5, InitialInterval: time.Hour}, nil, func(_ context.Context) error {
+		attempts++
+		cancel()
+		return errTest
+	})
+
+	require.ErrorIs(t, err, errTest)
+	assert.Equal(t, 1, attempts)
+}
+
+// waitForResult advances the fake clock in one second steps until the result is received
+func waitForResult(fakeClock *clock.FakeClock, result <-chan error, maxWait time.Duration) error {
+	for elapsed := time.Duration(0); elapsed <= maxWait; elapsed += time.Second {
+		select {
+		case err := <-result:
+			return err
+		case <-time.After(10 * time.Millisecond):
+			fakeClock.Advance(time.Second)
+		}
+	}
+	return <-result
+}
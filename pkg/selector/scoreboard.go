@@ -0,0 +1,133 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package selector
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+// Strategy defines how Select chooses among the candidate endpoints
+type Strategy string
+
+const (
+	// LeastFailures selects the endpoint with the fewest consecutive failed calls
+	LeastFailures Strategy = "leastFailures"
+	// LowestLatency selects the endpoint with the lowest average latency of its successful calls.
+	// Endpoints without any reported latency are selected first so they get measured.
+	LowestLatency Strategy = "lowestLatency"
+)
+
+// latencyWeight is the weight of the newest sample in the moving average of the latency
+const latencyWeight = 0.3
+
+// EndpointScore is the accumulated outcome of the calls made to an endpoint
+type EndpointScore struct {
+	Successes           uint64
+	Failures            uint64
+	ConsecutiveFailures uint64
+	// AverageLatency is the exponentially weighted moving average of the latency of successful calls
+	AverageLatency time.Duration
+}
+
+// Scoreboard keeps the outcome of the calls made to service endpoints, closing the loop between discovery
+// and the actual calls so selection favors the endpoints performing best. It is safe for concurrent use.
+type Scoreboard struct {
+	lock   sync.Mutex
+	scores map[string]EndpointScore
+}
+
+// NewScoreboard creates an empty Scoreboard
+func NewScoreboard() *Scoreboard {
+	return &Scoreboard{
+		scores: make(map[string]EndpointScore),
+	}
+}
+
+// ReportEndpointResult records the outcome and latency of a call made to the endpoint
+func (s *Scoreboard) ReportEndpointResult(endpoint types.ServiceEndpoint, success bool, latency time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := endpointKey(endpoint)
+	score := s.scores[key]
+
+	if !success {
+		score.Failures++
+		score.ConsecutiveFailures++
+		s.scores[key] = score
+		return
+	}
+
+	score.ConsecutiveFailures = 0
+	if score.Successes == 0 {
+		score.AverageLatency = latency
+	} else {
+		score.AverageLatency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(score.AverageLatency))
+	}
+	score.Successes++
+	s.scores[key] = score
+}
+
+// Score returns the accumulated score of the endpoint. The zero score is returned for endpoints without reported results.
+func (s *Scoreboard) Score(endpoint types.ServiceEndpoint) EndpointScore {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.scores[endpointKey(endpoint)]
+}
+
+// Select chooses one of the endpoints using the strategy. Ties are resolved by the order of the endpoints.
+func (s *Scoreboard) Select(endpoints []types.ServiceEndpoint, strategy Strategy) (types.ServiceEndpoint, error) {
+	if len(endpoints) == 0 {
+		return types.ServiceEndpoint{}, errors.New("no endpoints to select from")
+	}
+
+	var better func(candidate, best EndpointScore) bool
+	switch strategy {
+	case LeastFailures:
+		better = func(candidate, best EndpointScore) bool {
+			return candidate.ConsecutiveFailures < best.ConsecutiveFailures
+		}
+	case LowestLatency:
+		better = func(candidate, best EndpointScore) bool {
+			return candidate.AverageLatency < best.AverageLatency
+		}
+	default:
+		return types.ServiceEndpoint{}, fmt.Errorf("unknown selection strategy '%s'", strategy)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	selected := endpoints[0]
+	bestScore := s.scores[endpointKey(selected)]
+	for _, endpoint := range endpoints[1:] {
+		score := s.scores[endpointKey(endpoint)]
+		if better(score, bestScore) {
+			selected = endpoint
+			bestScore = score
+		}
+	}
+
+	return selected, nil
+}
+
+// Reset forgets the results reported for all endpoints
+func (s *Scoreboard) Reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.scores = make(map[string]EndpointScore)
+}
+
+func endpointKey(endpoint types.ServiceEndpoint) string {
+	return endpoint.ServiceId + "@" + endpoint.HostPort()
+}
@@ -0,0 +1,81 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package selector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+var (
+	endpointA = types.ServiceEndpoint{ServiceId: "core-data", Host: "10.0.0.1", Port: 59880}
+	endpointB = types.ServiceEndpoint{ServiceId: "core-data", Host: "10.0.0.2", Port: 59880}
+	endpointC = types.ServiceEndpoint{ServiceId: "core-data", Host: "10.0.0.3", Port: 59880}
+	endpoints = []types.ServiceEndpoint{endpointA, endpointB, endpointC}
+)
+
+func TestReportEndpointResult(t *testing.T) {
+	scoreboard := NewScoreboard()
+
+	scoreboard.ReportEndpointResult(endpointA, true, 100*time.Millisecond)
+	scoreboard.ReportEndpointResult(endpointA, false, time.Second)
+	scoreboard.ReportEndpointResult(endpointA, false, time.Second)
+	assert.Equal(t, EndpointScore{Successes: 1, Failures: 2, ConsecutiveFailures: 2, AverageLatency: 100 * time.Millisecond},
+		scoreboard.Score(endpointA))
+
+	scoreboard.ReportEndpointResult(endpointA, true, 200*time.Millisecond)
+	assert.Equal(t, EndpointScore{Successes: 2, Failures: 2, ConsecutiveFailures: 0, AverageLatency: 130 * time.Millisecond},
+		scoreboard.Score(endpointA))
+
+	assert.Equal(t, EndpointScore{}, scoreboard.Score(endpointB))
+
+	scoreboard.Reset()
+	assert.Equal(t, EndpointScore{}, scoreboard.Score(endpointA))
+}
+
+func TestSelect(t *testing.T) {
+	scoreboard := NewScoreboard()
+	scoreboard.ReportEndpointResult(endpointA, false, 0)
+	scoreboard.ReportEndpointResult(endpointA, false, 0)
+	scoreboard.ReportEndpointResult(endpointB, true, 300*time.Millisecond)
+	scoreboard.ReportEndpointResult(endpointC, true, 100*time.Millisecond)
+	scoreboard.ReportEndpointResult(endpointC, false, 0)
+
+	tests := []struct {
+		name      string
+		endpoints []types.ServiceEndpoint
+		strategy  Strategy
+		expected  types.ServiceEndpoint
+	}{
+		{"Least failures", endpoints, LeastFailures, endpointB},
+		{"Lowest latency", []types.ServiceEndpoint{endpointB, endpointC}, LowestLatency, endpointC},
+		{"Lowest latency prefers unmeasured", endpoints, LowestLatency, endpointA},
+		{"Single endpoint", []types.ServiceEndpoint{endpointA}, LeastFailures, endpointA},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			selected, err := scoreboard.Select(testCase.endpoints, testCase.strategy)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, selected)
+		})
+	}
+}
+
+func TestSelectErrors(t *testing.T) {
+	scoreboard := NewScoreboard()
+
+	_, err := scoreboard.Select(nil, LeastFailures)
+	require.Error(t, err)
+
+	_, err = scoreboard.Select(endpoints, "bogus")
+	require.Error(t, err)
+}
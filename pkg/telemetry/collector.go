@@ -0,0 +1,130 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package telemetry provides a MetricsReporter aggregating the calls to the registry service into counters and
+// durations, which are converted to the EdgeX telemetry metrics so they flow into the service metrics pipeline.
+package telemetry
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+const (
+	// CallsMetricName is the name of the metric reporting the calls of an operation, tagged with the backend and operation
+	CallsMetricName = "RegistryCalls"
+	// ClientMetricName is the name of the metric reporting the connectivity of the client, tagged with the backend
+	ClientMetricName = "RegistryClient"
+)
+
+// OperationMetrics holds the metrics of the calls to one operation of the registry service
+type OperationMetrics struct {
+	Backend   string
+	Operation string
+	// Calls is the number of calls, including the failed ones. Retried operations are counted once per attempt.
+	Calls int64
+	// Errors is the number of calls which failed
+	Errors        int64
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+}
+
+// Collector implements types.MetricsReporter, to be set as the MetricsReporter of the registry configuration
+type Collector struct {
+	lock       sync.Mutex
+	operations map[string]*OperationMetrics
+}
+
+// NewCollector creates a Collector with no calls collected
+func NewCollector() *Collector {
+	return &Collector{operations: make(map[string]*OperationMetrics)}
+}
+
+// ReportCall implements types.MetricsReporter
+func (c *Collector) ReportCall(backend string, operation string, duration time.Duration, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := backend + "/" + operation
+	metrics, ok := c.operations[key]
+	if !ok {
+		metrics = &OperationMetrics{Backend: backend, Operation: operation}
+		c.operations[key] = metrics
+	}
+
+	metrics.Calls++
+	if err != nil {
+		metrics.Errors++
+	}
+	metrics.TotalDuration += duration
+	metrics.MaxDuration = max(metrics.MaxDuration, duration)
+}
+
+// Operations returns the metrics collected so far, ordered by backend and operation
+func (c *Collector) Operations() []OperationMetrics {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	operations := make([]OperationMetrics, 0, len(c.operations))
+	for _, metrics := range c.operations {
+		operations = append(operations, *metrics)
+	}
+	slices.SortFunc(operations, func(a, b OperationMetrics) int {
+		return cmp.Or(cmp.Compare(a.Backend, b.Backend), cmp.Compare(a.Operation, b.Operation))
+	})
+
+	return operations
+}
+
+// Metrics converts the metrics collected so far, along with the connectivity from the ClientStats of the client, to
+// the EdgeX telemetry metrics. The durations are reported in milliseconds.
+func (c *Collector) Metrics(backend string, stats types.ClientStats) ([]dtos.Metric, error) {
+	operations := c.Operations()
+	metrics := make([]dtos.Metric, 0, len(operations)+1)
+	for _, operation := range operations {
+		var avgDuration time.Duration
+		if operation.Calls > 0 {
+			avgDuration = operation.TotalDuration / time.Duration(operation.Calls)
+		}
+
+		metric, err := dtos.NewMetric(CallsMetricName,
+			[]dtos.MetricField{
+				{Name: "calls", Value: operation.Calls},
+				{Name: "errors", Value: operation.Errors},
+				{Name: "avgDurationMs", Value: milliseconds(avgDuration)},
+				{Name: "maxDurationMs", Value: milliseconds(operation.MaxDuration)},
+			},
+			[]dtos.MetricTag{
+				{Name: "backend", Value: operation.Backend},
+				{Name: "operation", Value: operation.Operation},
+			})
+		if err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, metric)
+	}
+
+	metric, err := dtos.NewMetric(ClientMetricName,
+		[]dtos.MetricField{
+			{Name: "consecutiveFailures", Value: stats.ConsecutiveFailures},
+			{Name: "tokenRenewals", Value: stats.TokenRenewals},
+		},
+		[]dtos.MetricTag{{Name: "backend", Value: backend}})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(metrics, metric), nil
+}
+
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
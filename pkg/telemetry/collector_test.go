@@ -0,0 +1,62 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+var _ types.MetricsReporter = (*Collector)(nil)
+
+func TestCollector(t *testing.T) {
+	collector := NewCollector()
+	collector.ReportCall("keeper", "Ping", 2*time.Millisecond, nil)
+	collector.ReportCall("keeper", "Ping", 4*time.Millisecond, errors.New("connection refused"))
+	collector.ReportCall("keeper", "AllRegistry", 10*time.Millisecond, nil)
+
+	expected := []OperationMetrics{
+		{Backend: "keeper", Operation: "AllRegistry", Calls: 1, TotalDuration: 10 * time.Millisecond, MaxDuration: 10 * time.Millisecond},
+		{Backend: "keeper", Operation: "Ping", Calls: 2, Errors: 1, TotalDuration: 6 * time.Millisecond, MaxDuration: 4 * time.Millisecond},
+	}
+	assert.Equal(t, expected, collector.Operations())
+
+	metrics, err := collector.Metrics("keeper", types.ClientStats{ConsecutiveFailures: 1, TokenRenewals: 3})
+	require.NoError(t, err)
+	require.Len(t, metrics, 3)
+
+	ping := metrics[1]
+	assert.Equal(t, CallsMetricName, ping.Name)
+	assert.Equal(t, []dtos.MetricTag{{Name: "backend", Value: "keeper"}, {Name: "operation", Value: "Ping"}}, ping.Tags)
+	assert.Equal(t, []dtos.MetricField{
+		{Name: "calls", Value: int64(2)},
+		{Name: "errors", Value: int64(1)},
+		{Name: "avgDurationMs", Value: 3.0},
+		{Name: "maxDurationMs", Value: 4.0},
+	}, ping.Fields)
+
+	client := metrics[2]
+	assert.Equal(t, ClientMetricName, client.Name)
+	assert.Equal(t, []dtos.MetricField{
+		{Name: "consecutiveFailures", Value: 1},
+		{Name: "tokenRenewals", Value: 3},
+	}, client.Fields)
+}
+
+func TestCollectorEmpty(t *testing.T) {
+	metrics, err := NewCollector().Metrics("keeper", types.ClientStats{})
+	require.NoError(t, err)
+	require.Len(t, metrics, 1)
+	assert.Equal(t, ClientMetricName, metrics[0].Name)
+}
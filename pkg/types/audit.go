@@ -0,0 +1,29 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "time"
+
+// AuditRecord describes an operation changing the content of the registry service
+type AuditRecord struct {
+	// Actor is the service key of the service whose client performed the operation
+	Actor string
+	// Operation is the name of the client method, e.g. Register
+	Operation string
+	// Target identifies what the operation changed, i.e. the service key or the health check ID
+	Target string
+	// Timestamp is when the operation completed
+	Timestamp time.Time
+	// Err is the error the operation failed with. Nil if it succeeded.
+	Err error
+}
+
+// AuditSink receives a record of every operation changing the content of the registry service requested through the
+// client, e.g. Register and Unregister, so regulated deployments can forward them to their audit log. Records are
+// sent synchronously, so RecordAudit must return quickly and must not call the client back.
+type AuditSink interface {
+	RecordAudit(record AuditRecord)
+}
@@ -0,0 +1,42 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "errors"
+
+// Availability is the availability of a service, the same whichever registry reports it, so callers don't have to
+// match the error messages of each registry
+type Availability string
+
+const (
+	// AvailabilityAvailable indicates the service is registered and healthy
+	AvailabilityAvailable Availability = "available"
+	// AvailabilityNotRegistered indicates the service never registered or de-registered
+	AvailabilityNotRegistered Availability = "not registered"
+	// AvailabilityStarting indicates the service is registered but not healthy yet, within its health check grace period
+	AvailabilityStarting Availability = "starting"
+	// AvailabilityNotHealthy indicates the service is registered but its health check is not passing
+	AvailabilityNotHealthy Availability = "not healthy"
+	// AvailabilityUnknown indicates the availability couldn't be determined, e.g. because the registry is unreachable
+	AvailabilityUnknown Availability = "unknown"
+)
+
+// AvailabilityOf maps the result of IsServiceAvailable onto the availability of the service, based on the sentinel
+// error wrapped into the error
+func AvailabilityOf(available bool, err error) Availability {
+	switch {
+	case available:
+		return AvailabilityAvailable
+	case errors.Is(err, ErrNotRegistered), errors.Is(err, ErrServiceNotFound):
+		return AvailabilityNotRegistered
+	case errors.Is(err, ErrServiceStarting):
+		return AvailabilityStarting
+	case errors.Is(err, ErrServiceNotHealthy):
+		return AvailabilityNotHealthy
+	default:
+		return AvailabilityUnknown
+	}
+}
@@ -0,0 +1,38 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvailabilityOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		available bool
+		err       error
+		expected  Availability
+	}{
+		{"Available", true, nil, AvailabilityAvailable},
+		{"Never registered", false, fmt.Errorf("core-data service is not registered. Might not have started: %w", ErrNotRegistered), AvailabilityNotRegistered},
+		{"De-registered", false, fmt.Errorf("core-data service has been unregistered: %w", ErrNotRegistered), AvailabilityNotRegistered},
+		{"Not found", false, fmt.Errorf("failed to get core-data service registry: %w", ErrServiceNotFound), AvailabilityNotRegistered},
+		{"Starting", false, fmt.Errorf("core-data service not healthy yet: %w", ErrServiceStarting), AvailabilityStarting},
+		{"Not healthy", false, fmt.Errorf("core-data service not healthy: %w", ErrServiceNotHealthy), AvailabilityNotHealthy},
+		{"Registry unreachable", false, errors.New("connection refused"), AvailabilityUnknown},
+		{"No reason", false, nil, AvailabilityUnknown},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, AvailabilityOf(testCase.available, testCase.err))
+		})
+	}
+}
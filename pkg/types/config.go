@@ -18,23 +18,56 @@ package types
 
 import (
 	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/retry"
+)
+
+const (
+	defaultWatchInterval = 10 * time.Second
+
+	// UnixProtocol is the Protocol used to connect to a registry service listening on a unix domain socket
+	UnixProtocol = "unix"
 )
 
+// GetAccessTokenCallback is the callback used to obtain a new access token for the registry service
+type GetAccessTokenCallback func() (string, error)
+
 // Config defines the information need to connect to the registry service and optionally register the service
 // for discovery and health checks
 type Config struct {
 	// The Protocol that should be used to connect to the registry service. HTTP is used if not set.
+	// Set to UnixProtocol to connect over the unix domain socket specified by Host, which can't be combined with TLSConfig.
 	Protocol string
-	// Host is the hostname or IP address of the registry service
+	// Host is the hostname or IP address of the registry service, or the socket path when using UnixProtocol
 	Host string
 	// Port is the HTTP port of the registry service
 	Port int
+	// FailoverHosts are the "host:port" addresses of further replicas of the registry service, tried in order when the
+	// connection to Host and Port fails. The address which last worked is used first, so requests only fail over once.
+	// Not used with UnixProtocol.
+	FailoverHosts []string
 	// Type is the implementation type of the registry service, i.e. keeper
 	Type string
 	// ServiceKey is the key identifying the service for Registration and building the services base configuration path.
 	ServiceKey string
+	// Namespace is prefixed to the service keys sent to the registry service and stripped from the ones it returns, so
+	// multiple EdgeX instances can share one registry service without colliding, e.g. "site1" registers core-data as
+	// "site1.core-data". Only the services in the Namespace are discovered. Service keys are used as is if not set.
+	Namespace string
+	// InstanceId distinguishes the replicas of a service sharing the same ServiceKey, so they register as distinct
+	// instances rather than overwriting each other's registration. The ServiceKey is registered as is if not set.
+	InstanceId string
+	// ServiceKeyPolicy is the optional naming convention the ServiceKey must follow to be registered
+	ServiceKeyPolicy ServiceKeyPolicy
 	// ServiceHost is the hostname or IP address of the current running service using this module. May be left empty if not using registration
 	ServiceHost string
 	// ServicePort is the HTTP port of the current running service using this module. May be left unset if not using registration
@@ -43,10 +76,80 @@ type Config struct {
 	ServiceProtocol string
 	// Health check callback route for the current running service using this module. May be left empty if not using registration
 	CheckRoute string
+	// Health check callback host, when the registry service must reach the health check through a DNS name, such as a
+	// Kubernetes service name, rather than the registered ServiceHost. ServiceHost is used if not set.
+	// Keeper always checks a service on its registered address, so Register fails with ErrUnsupported if this differs.
+	CheckHost string
+	// Health check callback port, when the health check is served on a dedicated management port. ServicePort is used if not set.
+	// Keeper always checks a service on its registered address, so Register fails with ErrUnsupported if this differs.
+	CheckPort int
 	// Health check callback interval. May be left empty if not using registration
 	CheckInterval string
+	// Health check type, i.e. how the registry service checks the health of the current running service. "http" is used if not set.
+	CheckType string
+	// Health check grace period after registration, e.g. "30s", during which services which aren't healthy yet are reported as
+	// starting rather than unhealthy. No grace period is applied if not set.
+	CheckGracePeriod string
+	// HeartbeatInterval is how often the registration of the current service is refreshed while registered, e.g. "30s".
+	// This only updates the last modified time of the registration. Keeper doesn't use it to detect services which died,
+	// which is only done by its health checks, and the status they determined is kept. No heartbeat is sent if not set.
+	HeartbeatInterval string
+	// ReconcileInterval is how often the registration of the current service is checked against the registry while
+	// registered, e.g. "5m", re-applying it if it drifted, such as after it was edited, or registering the service again if
+	// the registry lost it, such as after restarting. The registration isn't checked if not set.
+	ReconcileInterval string
+	// WatchInterval is how often the registry is polled for changes by subscriptions, e.g. "10s". 10 seconds is used if not set.
+	WatchInterval string
+	// ChangeNotifier makes subscriptions event driven, checking the registry as soon as a change is notified rather than
+	// waiting for the next WatchInterval, which then only serves as a fallback. Only polling is used if not set.
+	ChangeNotifier RegistryChangeNotifier
 	// AuthInjector is an interface to obtain a JWT and secure transport for remote service calls
 	AuthInjector interfaces.AuthenticationInjector
+	// AccessTokenFile is the path to a file holding the access token for the registry service, such as a mounted secret.
+	// The file is read again whenever it changes, so rotated tokens are used without a restart. When GetAccessToken is also
+	// set, the most recently obtained token is used: a token renewed with GetAccessToken is used until the file changes,
+	// and the token read from the file is then used until the next renewal.
+	AccessTokenFile string
+	// GetAccessToken is called to obtain a new access token when the registry service rejects a request as unauthorized,
+	// after which the request is retried once. The token is then sent as a bearer token with every request.
+	GetAccessToken GetAccessTokenCallback
+	// AccessTokenRenewBefore is how long before the access token expires a new one is obtained with GetAccessToken, e.g. "30s",
+	// so requests aren't rejected first. The expiry is read from the exp claim of JWT tokens, or else derived from
+	// AccessTokenTTL. Tokens are only renewed once rejected if not set.
+	AccessTokenRenewBefore string
+	// AccessTokenTTL is how long the tokens obtained with GetAccessToken are valid for, e.g. "15m", used for tokens which
+	// don't carry their expiry
+	AccessTokenTTL string
+	// UserAgent is the User-Agent sent with every request to the registry service, so registry operators can attribute
+	// the traffic. The module name and version followed by the ServiceKey is used if not set.
+	UserAgent string
+	// TLSConfig holds the optional settings used when connecting to the registry service over HTTPS
+	TLSConfig TLSConfig
+	// HttpClient is an optional HTTP client used for all requests sent to the registry service. Takes precedence over Transport and TLSConfig
+	HttpClient *http.Client
+	// Transport is an optional HTTP transport used for all requests sent to the registry service. Takes precedence over TLSConfig
+	Transport http.RoundTripper
+	// HttpTransport holds the optional tuning of the connections to the registry service. Not applied to a caller provided
+	// HttpClient or Transport, nor to the transport provided by the AuthInjector, except for the RequestTimeout.
+	HttpTransport HttpTransportConfig
+	// EndpointOrder is the ordering applied to results containing multiple service endpoints. Ordered by service ID if not set.
+	EndpointOrder EndpointOrder
+	// EndpointOrderSeed is the seed used to shuffle the endpoints when EndpointOrder is random
+	EndpointOrderSeed uint64
+	// RetryPolicy is the retry policy applied to every call to the registry service which fails because the registry service
+	// is unavailable, e.g. Register() at start-up before the registry service is up. Calls are not retried if not set.
+	RetryPolicy retry.Policy
+	// RateLimit holds the optional client side limits on the rate of requests sent to the registry service
+	RateLimit RateLimitConfig
+	// Logger is used to log diagnostics such as retries and partial results. Nothing is logged if not set.
+	Logger logger.LoggingClient
+	// MetricsReporter is notified of every request sent to the registry service. Nothing is reported if not set.
+	MetricsReporter MetricsReporter
+	// AuditSink is notified of every operation changing the content of the registry service. Nothing is audited if not set.
+	AuditSink AuditSink
+	// Clock is the source of time for all time based logic such as retries and polling. The system clock is used if not set.
+	// Intended to be replaced with a fake clock in unit tests.
+	Clock clock.Clock
 	// EnableNameFieldEscape indicates whether enables NameFieldEscape in this service
 	// The name field escape could allow the system to use special or Chinese characters in the different name fields, including device, profile, and so on.  If the EnableNameFieldEscape is false, some special characters might cause system error.
 	// TODO: remove in EdgeX 4.0
@@ -57,16 +160,75 @@ type Config struct {
 // A few helper functions for building URLs.
 //
 
+// GetRegistryUrl returns the base URL of the registry service. When using UnixProtocol, the host is only a placeholder
+// as requests are sent over the socket.
 func (config Config) GetRegistryUrl() string {
-	return fmt.Sprintf("%s://%s:%v", config.GetRegistryProtocol(), config.Host, config.Port)
+	if config.IsUnixSocket() {
+		return "http://localhost"
+	}
+
+	return fmt.Sprintf("%s://%s", config.GetRegistryProtocol(), joinHostPort(config.Host, config.Port))
 }
 
+// GetHealthCheckUrl returns the URL the registry service calls to check the health of the current service. The check
+// type is used as the URL scheme, the same as the registry service does.
 func (config Config) GetHealthCheckUrl() string {
-	return config.GetExpandedRoute(config.CheckRoute)
+	return fmt.Sprintf("%s://%s%s", config.GetCheckType(), joinHostPort(config.GetCheckHost(), config.GetCheckPort()), config.CheckRoute)
+}
+
+func (config Config) GetCheckHost() string {
+	if config.CheckHost == "" {
+		return config.ServiceHost
+	}
+
+	return config.CheckHost
+}
+
+func (config Config) GetCheckPort() int {
+	if config.CheckPort == 0 {
+		return config.ServicePort
+	}
+
+	return config.CheckPort
+}
+
+func (config Config) GetCheckType() string {
+	if config.CheckType == "" {
+		return "http"
+	}
+
+	return config.CheckType
 }
 
 func (config Config) GetExpandedRoute(route string) string {
-	return fmt.Sprintf("%s://%s:%v%s", config.GetServiceProtocol(), config.ServiceHost, config.ServicePort, route)
+	return fmt.Sprintf("%s://%s%s", config.GetServiceProtocol(), joinHostPort(config.ServiceHost, config.ServicePort), route)
+}
+
+// parseOptionalDuration parses the duration setting, which is zero if not set
+func parseOptionalDuration(name string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s '%s': %v", name, value, err)
+	}
+	if duration < 0 {
+		return 0, fmt.Errorf("invalid %s '%s': must not be negative", name, value)
+	}
+
+	return duration, nil
+}
+
+// joinHostPort joins the host and port into an address, bracketing IPv6 hosts whether or not they are already bracketed
+func joinHostPort(host string, port int) string {
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+func (config Config) IsUnixSocket() bool {
+	return config.Protocol == UnixProtocol
 }
 
 func (config Config) GetRegistryProtocol() string {
@@ -84,3 +246,55 @@ func (config Config) GetServiceProtocol() string {
 
 	return config.ServiceProtocol
 }
+
+func (config Config) GetWatchInterval() (time.Duration, error) {
+	if config.WatchInterval == "" {
+		return defaultWatchInterval, nil
+	}
+
+	interval, err := time.ParseDuration(config.WatchInterval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid watch interval '%s': %v", config.WatchInterval, err)
+	}
+	if interval <= 0 {
+		return 0, fmt.Errorf("invalid watch interval '%s': must be positive", config.WatchInterval)
+	}
+
+	return interval, nil
+}
+
+func (config Config) GetCheckGracePeriod() (time.Duration, error) {
+	return parseOptionalDuration("health check grace period", config.CheckGracePeriod)
+}
+
+func (config Config) GetHeartbeatInterval() (time.Duration, error) {
+	return parseOptionalDuration("heartbeat interval", config.HeartbeatInterval)
+}
+
+func (config Config) GetReconcileInterval() (time.Duration, error) {
+	return parseOptionalDuration("reconcile interval", config.ReconcileInterval)
+}
+
+func (config Config) GetAccessTokenRenewBefore() (time.Duration, error) {
+	return parseOptionalDuration("access token renew before", config.AccessTokenRenewBefore)
+}
+
+func (config Config) GetAccessTokenTTL() (time.Duration, error) {
+	return parseOptionalDuration("access token TTL", config.AccessTokenTTL)
+}
+
+func (config Config) GetClock() clock.Clock {
+	if config.Clock == nil {
+		return clock.New()
+	}
+
+	return config.Clock
+}
+
+func (config Config) GetLogger() logger.LoggingClient {
+	if config.Logger == nil {
+		return logger.NewMockClient()
+	}
+
+	return config.Logger
+}
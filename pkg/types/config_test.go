@@ -0,0 +1,59 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHealthCheckUrl(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{"Service port", Config{ServiceHost: "core-data", ServicePort: 59880, CheckRoute: "/api/v3/ping"}, "http://core-data:59880/api/v3/ping"},
+		{"Management port", Config{ServiceHost: "core-data", ServicePort: 59880, CheckPort: 9090, CheckRoute: "/api/v3/ping"}, "http://core-data:9090/api/v3/ping"},
+		{"Check host", Config{ServiceHost: "10.42.0.7", ServicePort: 59880, CheckHost: "core-data.edgex.svc", CheckRoute: "/api/v3/ping"}, "http://core-data.edgex.svc:59880/api/v3/ping"},
+		{"Check type", Config{ServiceHost: "core-data", ServicePort: 59880, CheckType: "https", CheckRoute: "/api/v3/ping"}, "https://core-data:59880/api/v3/ping"},
+		{"Service protocol not used", Config{ServiceHost: "core-data", ServicePort: 59880, ServiceProtocol: "https", CheckRoute: "/api/v3/ping"}, "http://core-data:59880/api/v3/ping"},
+		{"IPv6 service host", Config{ServiceHost: "fd00::10", ServicePort: 59880, CheckRoute: "/api/v3/ping"}, "http://[fd00::10]:59880/api/v3/ping"},
+		{"Bracketed IPv6 service host", Config{ServiceHost: "[fd00::10]", ServicePort: 59880, CheckRoute: "/api/v3/ping"}, "http://[fd00::10]:59880/api/v3/ping"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.config.GetHealthCheckUrl())
+		})
+	}
+}
+
+func TestGetExpandedRoute(t *testing.T) {
+	config := Config{ServiceHost: "fd00::10", ServicePort: 59880}
+	assert.Equal(t, "http://[fd00::10]:59880/api/v3/ping", config.GetExpandedRoute("/api/v3/ping"))
+}
+
+func TestGetRegistryUrl(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{"Default protocol", Config{Host: "edgex-core-keeper", Port: 59890}, "http://edgex-core-keeper:59890"},
+		{"HTTPS", Config{Protocol: "https", Host: "edgex-core-keeper", Port: 59890}, "https://edgex-core-keeper:59890"},
+		{"Unix socket", Config{Protocol: UnixProtocol, Host: "/run/keeper.sock"}, "http://localhost"},
+		{"IPv6 host", Config{Host: "::1", Port: 59890}, "http://[::1]:59890"},
+		{"Bracketed IPv6 host", Config{Host: "[::1]", Port: 59890}, "http://[::1]:59890"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.config.GetRegistryUrl())
+		})
+	}
+}
@@ -0,0 +1,79 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Environment variables read by ConfigFromEnvironment
+const (
+	EnvRegistryProtocol           = "EDGEX_REGISTRY_PROTOCOL"
+	EnvRegistryHost               = "EDGEX_REGISTRY_HOST"
+	EnvRegistryPort               = "EDGEX_REGISTRY_PORT"
+	EnvRegistryType               = "EDGEX_REGISTRY_TYPE"
+	EnvRegistryServiceKey         = "EDGEX_REGISTRY_SERVICE_KEY"
+	EnvRegistryAccessTokenFile    = "EDGEX_REGISTRY_ACCESS_TOKEN_FILE"
+	EnvRegistryCAFile             = "EDGEX_REGISTRY_TLS_CA_FILE"
+	EnvRegistryCertFile           = "EDGEX_REGISTRY_TLS_CERT_FILE"
+	EnvRegistryKeyFile            = "EDGEX_REGISTRY_TLS_KEY_FILE"
+	EnvRegistryServerName         = "EDGEX_REGISTRY_TLS_SERVER_NAME"
+	EnvRegistryInsecureSkipVerify = "EDGEX_REGISTRY_TLS_INSECURE_SKIP_VERIFY"
+)
+
+// Defaults used by ConfigFromEnvironment for the unset environment variables
+const (
+	DefaultRegistryHost = "localhost"
+	DefaultRegistryPort = 59890
+	DefaultRegistryType = "keeper"
+)
+
+// ConfigFromEnvironment creates the configuration needed to connect to the registry service from the EDGEX_REGISTRY_*
+// environment variables, using the defaults for the ones not set. The access token is only read from a file, so it
+// doesn't leak through the environment. The service details needed for registration must still be set by the caller.
+func ConfigFromEnvironment() (Config, error) {
+	config := Config{
+		Protocol:        os.Getenv(EnvRegistryProtocol),
+		Host:            getEnv(EnvRegistryHost, DefaultRegistryHost),
+		Port:            DefaultRegistryPort,
+		Type:            getEnv(EnvRegistryType, DefaultRegistryType),
+		ServiceKey:      os.Getenv(EnvRegistryServiceKey),
+		AccessTokenFile: os.Getenv(EnvRegistryAccessTokenFile),
+		TLSConfig: TLSConfig{
+			CAFile:     os.Getenv(EnvRegistryCAFile),
+			CertFile:   os.Getenv(EnvRegistryCertFile),
+			KeyFile:    os.Getenv(EnvRegistryKeyFile),
+			ServerName: os.Getenv(EnvRegistryServerName),
+		},
+	}
+
+	if value := os.Getenv(EnvRegistryPort); value != "" {
+		port, err := strconv.Atoi(value)
+		if err != nil || port <= 0 || port > 65535 {
+			return Config{}, fmt.Errorf("invalid %s '%s': must be a port number", EnvRegistryPort, value)
+		}
+		config.Port = port
+	}
+
+	if value := os.Getenv(EnvRegistryInsecureSkipVerify); value != "" {
+		skipVerify, err := strconv.ParseBool(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s '%s': %v", EnvRegistryInsecureSkipVerify, value, err)
+		}
+		config.TLSConfig.InsecureSkipVerify = skipVerify
+	}
+
+	return config, nil
+}
+
+func getEnv(name string, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
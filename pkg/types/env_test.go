@@ -0,0 +1,59 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFromEnvironment(t *testing.T) {
+	config, err := ConfigFromEnvironment()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRegistryHost, config.Host)
+	assert.Equal(t, DefaultRegistryPort, config.Port)
+	assert.Equal(t, DefaultRegistryType, config.Type)
+	assert.False(t, config.TLSConfig.IsEnabled())
+
+	t.Setenv(EnvRegistryProtocol, "https")
+	t.Setenv(EnvRegistryHost, "edgex-core-keeper")
+	t.Setenv(EnvRegistryPort, "59891")
+	t.Setenv(EnvRegistryServiceKey, "core-data")
+	t.Setenv(EnvRegistryAccessTokenFile, "/run/secrets/keeper-token")
+	t.Setenv(EnvRegistryCAFile, "/run/secrets/ca.pem")
+	t.Setenv(EnvRegistryServerName, "keeper.edgex")
+	t.Setenv(EnvRegistryInsecureSkipVerify, "false")
+
+	config, err = ConfigFromEnvironment()
+	require.NoError(t, err)
+	assert.Equal(t, "https://edgex-core-keeper:59891", config.GetRegistryUrl())
+	assert.Equal(t, "core-data", config.ServiceKey)
+	assert.Equal(t, "/run/secrets/keeper-token", config.AccessTokenFile)
+	assert.Equal(t, "/run/secrets/ca.pem", config.TLSConfig.CAFile)
+	assert.Equal(t, "keeper.edgex", config.TLSConfig.ServerName)
+}
+
+func TestConfigFromEnvironmentInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   string
+		value string
+	}{
+		{"Port not a number", EnvRegistryPort, "keeper"},
+		{"Port out of range", EnvRegistryPort, "70000"},
+		{"Skip verify not a bool", EnvRegistryInsecureSkipVerify, "maybe"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv(testCase.env, testCase.value)
+			_, err := ConfigFromEnvironment()
+			require.Error(t, err)
+		})
+	}
+}
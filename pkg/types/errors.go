@@ -0,0 +1,87 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Sentinel errors wrapped into the errors returned by the registry clients, so callers can use errors.Is
+// rather than matching error messages which differ between backends
+var (
+	// ErrServiceNotFound indicates the registry has no registration for the requested service
+	ErrServiceNotFound = errors.New("service not found")
+	// ErrNotRegistered indicates the service is not registered, either because it never registered or because it de-registered
+	ErrNotRegistered = errors.New("service not registered")
+	// ErrServiceNotHealthy indicates the service is registered but its health check is not passing
+	ErrServiceNotHealthy = errors.New("service not healthy")
+	// ErrServiceStarting indicates the service is registered but not healthy yet, while still within its health check grace period
+	ErrServiceStarting = errors.New("service starting")
+	// ErrAccessDenied indicates the registry rejected the request due to missing or invalid credentials
+	ErrAccessDenied = errors.New("access denied")
+	// ErrInvalidServiceKey indicates the service key doesn't follow the configured ServiceKeyPolicy
+	ErrInvalidServiceKey = errors.New("invalid service key")
+	// ErrInvalidRegistration indicates the registration details are malformed, e.g. the port is out of range
+	ErrInvalidRegistration = errors.New("invalid registration")
+	// ErrUnsupported indicates the registry doesn't support the requested operation or settings
+	ErrUnsupported = errors.New("not supported by the registry")
+)
+
+// PartialResultError is returned together with the data that could be retrieved when the registry
+// only returned part of the requested data. Callers can use errors.As to detect it and decide whether
+// the partial data is acceptable.
+type PartialResultError struct {
+	// Incomplete holds the IDs of the services whose data was returned incomplete and has been left out of the result
+	Incomplete []string
+	// Missing is the number of entries the registry reported but did not return
+	Missing int
+}
+
+func (e *PartialResultError) Error() string {
+	var details []string
+	if len(e.Incomplete) > 0 {
+		details = append(details, fmt.Sprintf("incomplete data for services %s", strings.Join(e.Incomplete, ", ")))
+	}
+	if e.Missing > 0 {
+		details = append(details, fmt.Sprintf("%d entries missing", e.Missing))
+	}
+
+	return fmt.Sprintf("registry returned partial result: %s", strings.Join(details, "; "))
+}
+
+// BatchRegistrationError is returned when registering multiple services at once and some of them fail to register.
+// The other services are registered regardless. Callers can use errors.As to find out which services failed.
+type BatchRegistrationError struct {
+	// Failed holds the errors of the services which failed to register, keyed by service key
+	Failed map[string]error
+}
+
+func (e *BatchRegistrationError) Error() string {
+	keys := make([]string, 0, len(e.Failed))
+	for key := range e.Failed {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	details := make([]string, 0, len(keys))
+	for _, key := range keys {
+		details = append(details, fmt.Sprintf("%s: %v", key, e.Failed[key]))
+	}
+
+	return fmt.Sprintf("failed to register %d services: %s", len(keys), strings.Join(details, "; "))
+}
+
+// Unwrap returns the errors of the failed services, so errors.Is finds the sentinel errors they wrap
+func (e *BatchRegistrationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
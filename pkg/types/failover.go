@@ -0,0 +1,38 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// GetRegistryAddresses returns the "host:port" addresses of the registry service replicas, starting with Host and Port
+// followed by the FailoverHosts in the order they are configured. No addresses are returned when using UnixProtocol, as
+// the requests are sent over the socket.
+func (config Config) GetRegistryAddresses() ([]string, error) {
+	if config.IsUnixSocket() {
+		return nil, nil
+	}
+
+	addresses := []string{net.JoinHostPort(config.Host, strconv.Itoa(config.Port))}
+	for _, address := range config.FailoverHosts {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid failover host '%s': %v", address, err)
+		}
+		if host == "" {
+			return nil, fmt.Errorf("invalid failover host '%s': missing host", address)
+		}
+		if number, err := strconv.Atoi(port); err != nil || number < 1 || number > 65535 {
+			return nil, fmt.Errorf("invalid failover host '%s': invalid port '%s'", address, port)
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
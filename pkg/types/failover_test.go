@@ -0,0 +1,50 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRegistryAddresses(t *testing.T) {
+	tests := []struct {
+		name          string
+		protocol      string
+		failoverHosts []string
+		expected      []string
+		expectError   bool
+	}{
+		{"No failover", "", nil, []string{"keeper-1:59890"}, false},
+		{"Failover", "", []string{"keeper-2:59890", "[::1]:59891"}, []string{"keeper-1:59890", "keeper-2:59890", "[::1]:59891"}, false},
+		{"Unix socket", UnixProtocol, []string{"keeper-2:59890"}, nil, false},
+		{"Missing port", "", []string{"keeper-2"}, nil, true},
+		{"Missing host", "", []string{":59890"}, nil, true},
+		{"Invalid port", "", []string{"keeper-2:http"}, nil, true},
+		{"Port out of range", "", []string{"keeper-2:65536"}, nil, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := Config{
+				Protocol:      testCase.protocol,
+				Host:          "keeper-1",
+				Port:          59890,
+				FailoverHosts: testCase.failoverHosts,
+			}
+
+			addresses, err := config.GetRegistryAddresses()
+			if testCase.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, addresses)
+		})
+	}
+}
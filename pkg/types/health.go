@@ -0,0 +1,72 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "time"
+
+// HealthEvent describes a health transition of a watched service
+type HealthEvent struct {
+	// ServiceId is the ID of the service which changed health
+	ServiceId string
+	// Healthy is true when the service became healthy and false when it became unhealthy
+	Healthy bool
+	// Status is the new status reported by the registry, e.g. UP or DOWN. Empty if the service is no longer registered.
+	Status string
+	// Timestamp is when the transition was observed
+	Timestamp time.Time
+}
+
+// RegistrationEvent describes a service being added to or removed from the registry
+type RegistrationEvent struct {
+	// Registered is true when the service was added to the registry and false when it was removed
+	Registered bool
+	// Endpoint is the endpoint of the service, as last seen while it was registered
+	Endpoint ServiceEndpoint
+	// Timestamp is when the change was observed
+	Timestamp time.Time
+}
+
+// AliveStatus describes whether the registry service is up and running, and if not, why
+type AliveStatus struct {
+	// Alive is true when the registry service is reachable and responded successfully
+	Alive bool
+	// Reachable is true when the registry service responded, successfully or not
+	Reachable bool
+	// Latency is the round-trip time of the check, i.e. of its last attempt if retried, excluding client side rate limits
+	Latency time.Duration
+	// StatusCode is the HTTP status code the registry service responded with. Zero if it wasn't reachable.
+	StatusCode int
+	// TLSError is the TLS error which prevented the connection to the registry service, e.g. an untrusted certificate
+	TLSError error
+}
+
+// HealthCheckResult is the latest result of one of the health checks of a service
+type HealthCheckResult struct {
+	// Name identifies the check within the service
+	Name string
+	// Status is the status resulting from the check, e.g. UP or DOWN
+	Status string
+	// Output is the output of the check, explaining the status. Empty if the registry doesn't report it.
+	Output string
+	// Interval is how often the check is run, e.g. 10s
+	Interval string
+	// LastRun is when the check last ran. Zero if the registry doesn't report it.
+	LastRun time.Time
+}
+
+// ClientStats describes the connectivity of the registry client to the registry service, so consuming services can
+// include it in their own health and metrics output
+type ClientStats struct {
+	// LastSuccess is when the registry service last responded. Zero if it never did.
+	LastSuccess time.Time
+	// LastFailure is when a request last failed to get a response, or the registry service last failed internally.
+	// Zero if it never happened.
+	LastFailure time.Time
+	// ConsecutiveFailures is the number of requests which failed since the registry service last responded
+	ConsecutiveFailures int
+	// TokenRenewals is the number of times the access token has been renewed
+	TokenRenewals int
+}
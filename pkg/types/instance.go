@@ -0,0 +1,56 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// instanceSeparator separates the service key from the instance ID in the keys the replicas of a service are registered with
+const instanceSeparator = "~"
+
+// ValidateInstanceId checks the InstanceId only contains letters, digits, '-', '_' and '.', so the instance keys can be
+// used as is in URL paths, and that the ServiceKey doesn't contain the instance separator, so the keys the service is
+// registered with are split back into the service key and instance ID they were built from
+func (config Config) ValidateInstanceId() error {
+	return validateInstanceId(config.ServiceKey, config.InstanceId)
+}
+
+// GetInstanceKey returns the key the current service is registered with, i.e. the ServiceKey followed by the InstanceId,
+// e.g. "core-data~replica-1". The ServiceKey is returned as is if no InstanceId is set.
+func (config Config) GetInstanceKey() string {
+	return instanceKey(config.ServiceKey, config.InstanceId)
+}
+
+// SplitInstanceKey splits the key a service is registered with into the service key and the instance ID, which is
+// empty if the service didn't register as a distinct instance
+func SplitInstanceKey(instanceKey string) (string, string) {
+	serviceKey, instanceId, _ := strings.Cut(instanceKey, instanceSeparator)
+	return serviceKey, instanceId
+}
+
+func validateInstanceId(serviceKey string, instanceId string) error {
+	if strings.Contains(serviceKey, instanceSeparator) {
+		return fmt.Errorf("service key '%s' contains the instance separator '%s'", serviceKey, instanceSeparator)
+	}
+
+	for _, c := range instanceId {
+		if !isServiceKeyCharacter(c) {
+			return fmt.Errorf("instance ID '%s' contains invalid character '%c'", instanceId, c)
+		}
+	}
+
+	return nil
+}
+
+func instanceKey(serviceKey string, instanceId string) string {
+	if instanceId == "" {
+		return serviceKey
+	}
+
+	return serviceKey + instanceSeparator + instanceId
+}
@@ -0,0 +1,50 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstanceKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceKey  string
+		instanceId  string
+		expectedKey string
+		expectError bool
+	}{
+		{"No instance", "core-data", "", "core-data", false},
+		{"Instance", "core-data", "replica-1", "core-data~replica-1", false},
+		{"Separator", "core-data", "replica~1", "", true},
+		{"Slash", "core-data", "replica/1", "", true},
+		{"Separator in service key", "core~data", "", "", true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := Config{ServiceKey: testCase.serviceKey, InstanceId: testCase.instanceId}
+			if testCase.expectError {
+				assert.Error(t, config.ValidateInstanceId())
+				assert.Error(t, ServiceRegistration{ServiceKey: testCase.serviceKey, InstanceId: testCase.instanceId}.ValidateInstanceId())
+				return
+			}
+			assert.NoError(t, config.ValidateInstanceId())
+
+			key := config.GetInstanceKey()
+			assert.Equal(t, testCase.expectedKey, key)
+			registration := ServiceRegistration{ServiceKey: testCase.serviceKey, InstanceId: testCase.instanceId}
+			assert.NoError(t, registration.ValidateInstanceId())
+			assert.Equal(t, key, registration.GetInstanceKey())
+
+			serviceKey, instanceId := SplitInstanceKey(key)
+			assert.Equal(t, "core-data", serviceKey)
+			assert.Equal(t, testCase.instanceId, instanceId)
+		})
+	}
+}
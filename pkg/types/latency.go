@@ -0,0 +1,47 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"slices"
+	"time"
+)
+
+// LatencyReport summarizes the round-trip times of a series of pings to the registry service
+type LatencyReport struct {
+	// Samples is the number of pings which got a response and are included in the statistics
+	Samples int
+	// Failed is the number of pings which didn't get a response
+	Failed int
+	Min    time.Duration
+	Avg    time.Duration
+	Max    time.Duration
+	// P95 is the 95th percentile, using the nearest-rank method
+	P95 time.Duration
+}
+
+// NewLatencyReport computes the statistics of the round-trip times of the successful pings
+func NewLatencyReport(latencies []time.Duration, failed int) LatencyReport {
+	report := LatencyReport{Samples: len(latencies), Failed: failed}
+	if len(latencies) == 0 {
+		return report
+	}
+
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+
+	report.Min = sorted[0]
+	report.Max = sorted[len(sorted)-1]
+	report.Avg = total / time.Duration(len(sorted))
+	// Nearest rank is ceil(0.95 * n), computed with integers
+	report.P95 = sorted[(95*len(sorted)+99)/100-1]
+	return report
+}
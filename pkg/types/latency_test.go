@@ -0,0 +1,41 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLatencyReport(t *testing.T) {
+	manyLatencies := make([]time.Duration, 0, 20)
+	for i := 20; i > 0; i-- {
+		manyLatencies = append(manyLatencies, time.Duration(i)*time.Millisecond)
+	}
+
+	tests := []struct {
+		name      string
+		latencies []time.Duration
+		failed    int
+		expected  LatencyReport
+	}{
+		{"No samples", nil, 3, LatencyReport{Failed: 3}},
+		{"Single sample", []time.Duration{5 * time.Millisecond}, 0,
+			LatencyReport{Samples: 1, Min: 5 * time.Millisecond, Avg: 5 * time.Millisecond, Max: 5 * time.Millisecond, P95: 5 * time.Millisecond}},
+		{"Unsorted samples", []time.Duration{3 * time.Millisecond, time.Millisecond, 2 * time.Millisecond}, 1,
+			LatencyReport{Samples: 3, Failed: 1, Min: time.Millisecond, Avg: 2 * time.Millisecond, Max: 3 * time.Millisecond, P95: 3 * time.Millisecond}},
+		{"Many samples", manyLatencies, 0,
+			LatencyReport{Samples: 20, Min: time.Millisecond, Avg: 10500 * time.Microsecond, Max: 20 * time.Millisecond, P95: 19 * time.Millisecond}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, NewLatencyReport(testCase.latencies, testCase.failed))
+		})
+	}
+}
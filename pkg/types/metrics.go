@@ -0,0 +1,17 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "time"
+
+// MetricsReporter receives the outcome of every request sent to the registry service, so consuming services can
+// feed call counts, error counts and durations into their telemetry without this module depending on a metrics library.
+// The telemetry package provides an implementation converting them to the EdgeX telemetry metrics.
+type MetricsReporter interface {
+	// ReportCall is called once per request with the registry type, e.g. keeper, the operation name, how long the
+	// request took and the resulting error, if any. Retried operations are reported once per attempt.
+	ReportCall(backend string, operation string, duration time.Duration, err error)
+}
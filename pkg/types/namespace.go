@@ -0,0 +1,46 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// namespaceSeparator separates the namespace from the service key in the keys registered with the registry service
+const namespaceSeparator = "."
+
+// ValidateNamespace checks the Namespace only contains letters, digits, '-' and '_', so the namespaced keys can be
+// used as is in URL paths and the namespace can't be mistaken for part of the service key
+func (config Config) ValidateNamespace() error {
+	for _, c := range config.Namespace {
+		if !isServiceKeyCharacter(c) || string(c) == namespaceSeparator {
+			return fmt.Errorf("namespace '%s' contains invalid character '%c'", config.Namespace, c)
+		}
+	}
+
+	return nil
+}
+
+// GetNamespacedKey returns the key the service is known by in the registry service, i.e. the service key prefixed with
+// the Namespace. The service key is returned as is if no Namespace is set.
+func (config Config) GetNamespacedKey(serviceKey string) string {
+	if config.Namespace == "" {
+		return serviceKey
+	}
+
+	return config.Namespace + namespaceSeparator + serviceKey
+}
+
+// StripNamespace returns the service key from the key a service is known by in the registry service, and whether the
+// service belongs to the Namespace. All services belong to it if no Namespace is set.
+func (config Config) StripNamespace(namespacedKey string) (string, bool) {
+	if config.Namespace == "" {
+		return namespacedKey, true
+	}
+
+	return strings.CutPrefix(namespacedKey, config.Namespace+namespaceSeparator)
+}
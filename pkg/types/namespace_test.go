@@ -0,0 +1,66 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNamespace(t *testing.T) {
+	tests := []struct {
+		name        string
+		namespace   string
+		expectError bool
+	}{
+		{"Not set", "", false},
+		{"Valid", "site-1_a", false},
+		{"Instance separator", "site~1", true},
+		{"Separator", "site.1", true},
+		{"Slash", "site/1", true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := Config{Namespace: testCase.namespace}.ValidateNamespace()
+			if testCase.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNamespacedKey(t *testing.T) {
+	tests := []struct {
+		name             string
+		namespace        string
+		serviceKey       string
+		expectedKey      string
+		otherKey         string
+		expectedInOthers bool
+	}{
+		{"No namespace", "", "core-data", "core-data", "site2.core-data", true},
+		{"Namespace", "site1", "core-data", "site1.core-data", "site2.core-data", false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := Config{Namespace: testCase.namespace}
+			key := config.GetNamespacedKey(testCase.serviceKey)
+			assert.Equal(t, testCase.expectedKey, key)
+
+			serviceKey, inNamespace := config.StripNamespace(key)
+			assert.True(t, inNamespace)
+			assert.Equal(t, testCase.serviceKey, serviceKey)
+
+			_, inNamespace = config.StripNamespace(testCase.otherKey)
+			assert.Equal(t, testCase.expectedInOthers, inNamespace)
+		})
+	}
+}
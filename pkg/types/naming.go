@@ -0,0 +1,69 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+const defaultServiceKeyMaxLength = 128
+
+// ServiceKeyPolicy defines the optional naming convention enforced on the service key when registering,
+// preventing malformed keys which later break URL building and ACL policies
+type ServiceKeyPolicy struct {
+	// Enabled turns on the enforcement of the naming convention. Service keys are not checked if not set.
+	Enabled bool
+	// Prefixes lists the prefixes of which the service key must start with one, e.g. "core-", "device-" and "app-".
+	// Any prefix is accepted if not set.
+	Prefixes []string
+	// MaxLength is the maximum length of the service key. 128 is used if not set.
+	MaxLength int
+}
+
+// Validate checks the service key follows the naming convention. Besides the configured prefixes and length, the
+// service key must only contain letters, digits, '-', '_' and '.' so it can be used as is in URL paths. '~' is excluded
+// as it separates the service key from the InstanceId in the keys the service replicas are registered with.
+func (p ServiceKeyPolicy) Validate(serviceKey string) error {
+	if !p.Enabled {
+		return nil
+	}
+
+	if serviceKey == "" {
+		return fmt.Errorf("%w: service key is empty", ErrInvalidServiceKey)
+	}
+
+	maxLength := p.MaxLength
+	if maxLength <= 0 {
+		maxLength = defaultServiceKeyMaxLength
+	}
+	if len(serviceKey) > maxLength {
+		return fmt.Errorf("%w: service key '%s' is longer than %d characters", ErrInvalidServiceKey, serviceKey, maxLength)
+	}
+
+	for _, c := range serviceKey {
+		if !isServiceKeyCharacter(c) {
+			return fmt.Errorf("%w: service key '%s' contains invalid character '%c'", ErrInvalidServiceKey, serviceKey, c)
+		}
+	}
+
+	if len(p.Prefixes) == 0 {
+		return nil
+	}
+	for _, prefix := range p.Prefixes {
+		if strings.HasPrefix(serviceKey, prefix) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: service key '%s' must start with one of %s", ErrInvalidServiceKey, serviceKey, strings.Join(p.Prefixes, ", "))
+}
+
+// isServiceKeyCharacter returns true for the unreserved URL characters, except for the instance separator
+func isServiceKeyCharacter(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c == '-' || c == '_' || c == '.'
+}
@@ -0,0 +1,48 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceKeyPolicyValidate(t *testing.T) {
+	edgexPolicy := ServiceKeyPolicy{Enabled: true, Prefixes: []string{"core-", "device-", "app-"}}
+
+	tests := []struct {
+		name        string
+		policy      ServiceKeyPolicy
+		serviceKey  string
+		expectError bool
+	}{
+		{"Disabled", ServiceKeyPolicy{Prefixes: []string{"core-"}}, "bad key/", false},
+		{"Valid", edgexPolicy, "core-data", false},
+		{"Valid any prefix", ServiceKeyPolicy{Enabled: true}, "my_service.v2", false},
+		{"Instance separator", ServiceKeyPolicy{Enabled: true}, "my_service~x", true},
+		{"Empty", edgexPolicy, "", true},
+		{"Wrong prefix", edgexPolicy, "support-notifications", true},
+		{"Invalid character", edgexPolicy, "core-data/1", true},
+		{"Whitespace", edgexPolicy, "core- data", true},
+		{"Too long default", ServiceKeyPolicy{Enabled: true}, strings.Repeat("a", 129), true},
+		{"Too long configured", ServiceKeyPolicy{Enabled: true, MaxLength: 8}, "core-data", true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.policy.Validate(testCase.serviceKey)
+			if testCase.expectError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidServiceKey)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
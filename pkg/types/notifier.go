@@ -0,0 +1,17 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "context"
+
+// RegistryChangeNotifier delivers the registry change notifications published by the registry service, such as those
+// Core Keeper publishes on the EdgeX message bus. Callers adapt their messaging client to it, so this module doesn't
+// depend on a particular messaging implementation.
+type RegistryChangeNotifier interface {
+	// Subscribe calls the handler with the ID of the changed service for every notification until the context is done.
+	// An empty ID means the changed service is unknown.
+	Subscribe(ctx context.Context, handler func(serviceId string)) error
+}
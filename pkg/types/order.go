@@ -0,0 +1,46 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// EndpointOrder defines the ordering applied to results containing multiple service endpoints
+type EndpointOrder string
+
+const (
+	// EndpointOrderServiceId orders the endpoints by service ID. This is the default ordering.
+	EndpointOrderServiceId EndpointOrder = "serviceId"
+	// EndpointOrderHealth orders healthy endpoints before unhealthy ones, then by service ID
+	EndpointOrderHealth EndpointOrder = "health"
+	// EndpointOrderRandom shuffles the endpoints using the configured seed, so the same seed always gives the same order
+	EndpointOrderRandom EndpointOrder = "random"
+	// EndpointOrderLastUpdated orders the most recently updated endpoints first, then by service ID
+	EndpointOrderLastUpdated EndpointOrder = "lastUpdated"
+)
+
+// ListOptions holds the optional settings of the listing of service endpoints
+type ListOptions struct {
+	// Order is the ordering of the endpoints. The configured EndpointOrder is used if not set.
+	Order EndpointOrder
+	// Descending reverses the ordering of the endpoints
+	Descending bool
+	// ServiceIdFilter selects the endpoints whose service ID matches the regular expression, e.g.
+	// `^app-service-(export|rules)$`. All the endpoints are listed if not set.
+	ServiceIdFilter *regexp.Regexp
+}
+
+// Validate checks the EndpointOrder is one of the supported orderings. An empty value is valid and means EndpointOrderServiceId.
+func (o EndpointOrder) Validate() error {
+	switch o {
+	case "", EndpointOrderServiceId, EndpointOrderHealth, EndpointOrderRandom, EndpointOrderLastUpdated:
+		return nil
+	default:
+		return fmt.Errorf("unknown endpoint order '%s'", o)
+	}
+}
@@ -0,0 +1,17 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+// RateLimitConfig defines the client side limits on the rate of requests sent to the registry service.
+// Reads, i.e. discovery and health queries, and writes, i.e. registration changes, are limited separately.
+type RateLimitConfig struct {
+	// ReadsPerSecond is the average rate of read requests allowed. Reads are not limited if zero.
+	ReadsPerSecond float64
+	// WritesPerSecond is the average rate of write requests allowed. Writes are not limited if zero.
+	WritesPerSecond float64
+	// Burst is the number of requests of each kind allowed at once before the rate applies. 1 is used if not set.
+	Burst int
+}
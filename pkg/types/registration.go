@@ -0,0 +1,109 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ServiceRegistration holds the details needed to register a service, for registering services other than the
+// current one, e.g. when a single process hosts multiple logical services. Serialized to JSON by the snapshots
+// of the registry produced by ExportRegistrations.
+type ServiceRegistration struct {
+	ServiceKey string `json:"serviceKey"`
+	// InstanceId distinguishes the replicas of the service, which are registered with the ServiceKey followed by the
+	// InstanceId, the same as the current service when its InstanceId is set
+	InstanceId string `json:"instanceId,omitempty"`
+	Host       string `json:"host"`
+	Port       int    `json:"port"`
+	// CheckRoute is the route of the service the registry calls to check its health
+	CheckRoute string `json:"checkRoute,omitempty"`
+	// CheckInterval is how often the registry checks the health of the service, e.g. 10s
+	CheckInterval string `json:"checkInterval,omitempty"`
+	// CheckType is the type of health check, e.g. http or https. Defaults to http if not set.
+	CheckType string `json:"checkType,omitempty"`
+}
+
+// GetCheckType returns the type of health check, defaulting to http
+func (r ServiceRegistration) GetCheckType() string {
+	if r.CheckType == "" {
+		return "http"
+	}
+
+	return r.CheckType
+}
+
+// GetInstanceKey returns the key the service is registered with, i.e. the ServiceKey followed by the InstanceId if set
+func (r ServiceRegistration) GetInstanceKey() string {
+	return instanceKey(r.ServiceKey, r.InstanceId)
+}
+
+// ValidateInstanceId checks the InstanceId only contains letters, digits, '-', '_' and '.' and that the ServiceKey
+// doesn't contain the instance separator, the same as for the current service
+func (r ServiceRegistration) ValidateInstanceId() error {
+	return validateInstanceId(r.ServiceKey, r.InstanceId)
+}
+
+// Validate checks the registration is well-formed, so a malformed registration fails before being sent with an error
+// wrapping ErrInvalidRegistration and listing every problem, rather than being rejected by the registry
+func (r ServiceRegistration) Validate() error {
+	var errs []error
+	if r.ServiceKey == "" {
+		errs = append(errs, errors.New("service key is not set"))
+	}
+	if err := r.ValidateInstanceId(); err != nil {
+		errs = append(errs, err)
+	}
+	if r.Host == "" {
+		errs = append(errs, errors.New("host is not set"))
+	}
+	if r.Port < 1 || r.Port > 65535 {
+		errs = append(errs, fmt.Errorf("port %d is not between 1 and 65535", r.Port))
+	}
+	if !strings.HasPrefix(r.CheckRoute, "/") {
+		errs = append(errs, fmt.Errorf("health check route '%s' must start with '/'", r.CheckRoute))
+	}
+	if interval, err := time.ParseDuration(r.CheckInterval); err != nil {
+		errs = append(errs, fmt.Errorf("invalid health check interval '%s': %v", r.CheckInterval, err))
+	} else if interval <= 0 {
+		errs = append(errs, fmt.Errorf("invalid health check interval '%s': must be positive", r.CheckInterval))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidRegistration, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// RegisterOptions holds the optional behavior of registering the current service
+type RegisterOptions struct {
+	// DryRun checks the registration could be made, without registering the service
+	DryRun bool
+}
+
+// RegisterOption sets one of the RegisterOptions
+type RegisterOption func(options *RegisterOptions)
+
+// WithDryRun makes Register validate the registration, resolve the health check URL and check the access to the
+// registry without registering the service, e.g. to validate the deployment configuration in CI
+func WithDryRun() RegisterOption {
+	return func(options *RegisterOptions) {
+		options.DryRun = true
+	}
+}
+
+// NewRegisterOptions applies the options to the default RegisterOptions
+func NewRegisterOptions(options ...RegisterOption) RegisterOptions {
+	var registerOptions RegisterOptions
+	for _, option := range options {
+		option(&registerOptions)
+	}
+	return registerOptions
+}
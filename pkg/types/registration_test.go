@@ -0,0 +1,64 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceRegistrationValidate(t *testing.T) {
+	valid := ServiceRegistration{
+		ServiceKey:    "core-data",
+		Host:          "localhost",
+		Port:          59880,
+		CheckRoute:    "/api/v3/ping",
+		CheckInterval: "10s",
+	}
+
+	tests := []struct {
+		name          string
+		modify        func(registration *ServiceRegistration)
+		expectedError string
+	}{
+		{"Valid", func(registration *ServiceRegistration) {}, ""},
+		{"No service key", func(registration *ServiceRegistration) { registration.ServiceKey = "" }, "service key is not set"},
+		{"Instance", func(registration *ServiceRegistration) { registration.InstanceId = "replica-1" }, ""},
+		{"Separator in service key", func(registration *ServiceRegistration) { registration.ServiceKey = "core-data~replica-1" }, "instance separator"},
+		{"Invalid instance", func(registration *ServiceRegistration) { registration.InstanceId = "replica/1" }, "instance ID 'replica/1'"},
+		{"No host", func(registration *ServiceRegistration) { registration.Host = "" }, "host is not set"},
+		{"Port out of range", func(registration *ServiceRegistration) { registration.Port = 70000 }, "port 70000"},
+		{"No port", func(registration *ServiceRegistration) { registration.Port = 0 }, "port 0"},
+		{"Relative route", func(registration *ServiceRegistration) { registration.CheckRoute = "api/v3/ping" }, "must start with '/'"},
+		{"Invalid interval", func(registration *ServiceRegistration) { registration.CheckInterval = "10" }, "invalid health check interval"},
+		{"Zero interval", func(registration *ServiceRegistration) { registration.CheckInterval = "0s" }, "must be positive"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			registration := valid
+			testCase.modify(&registration)
+
+			err := registration.Validate()
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidRegistration)
+			assert.Contains(t, err.Error(), testCase.expectedError)
+		})
+	}
+}
+
+func TestServiceRegistrationValidateAllProblems(t *testing.T) {
+	err := ServiceRegistration{}.Validate()
+	require.ErrorIs(t, err, ErrInvalidRegistration)
+	for _, expected := range []string{"service key", "host", "port", "route", "interval"} {
+		assert.Contains(t, err.Error(), expected)
+	}
+}
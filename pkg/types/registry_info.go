@@ -0,0 +1,32 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+// Modes in which the registry client authenticates with the registry service, reported by RegistryInfo
+const (
+	// AuthModeNone indicates requests are sent without credentials
+	AuthModeNone = "none"
+	// AuthModeToken indicates requests carry the access token from AccessTokenFile or GetAccessToken
+	AuthModeToken = "token"
+	// AuthModeInjector indicates credentials are added to requests by the AuthInjector
+	AuthModeInjector = "injector"
+)
+
+// RegistryInfo describes the registry service the client is connected to, for diagnostics and support bundles
+type RegistryInfo struct {
+	// Type is the implementation type of the registry service, e.g. keeper
+	Type string
+	// Url is the base URL of the registry service
+	Url string
+	// ServiceName is the name the registry service reports for itself
+	ServiceName string
+	// Version is the version of the registry service
+	Version string
+	// ApiVersion is the version of the API served by the registry service
+	ApiVersion string
+	// AuthMode is how the client authenticates with the registry service, e.g. AuthModeToken
+	AuthMode string
+}
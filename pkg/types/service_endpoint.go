@@ -16,9 +16,37 @@
 
 package types
 
+import (
+	"time"
+)
+
 // ServiceEndpoint defines the service information returned by GetServiceEndpoint() need to connect to the target service
 type ServiceEndpoint struct {
 	ServiceId string
-	Host      string
-	Port      int
+	// InstanceId identifies the replica of the service. Empty if the service didn't register as a distinct instance.
+	InstanceId string
+	Host       string
+	Port       int
+	// Protocol is the scheme used to call the service, e.g. http or https. May be empty if the registry doesn't know it.
+	Protocol string
+	// HealthStatus is the health status the registry reports for the service, e.g. UP or DOWN
+	HealthStatus string
+	// LastUpdated is when the registration last changed. Zero if the registry doesn't report it.
+	LastUpdated time.Time
+}
+
+// HostPort returns the host and port of the endpoint joined as an address, bracketing IPv6 hosts
+func (e ServiceEndpoint) HostPort() string {
+	return joinHostPort(e.Host, e.Port)
+}
+
+// BaseURL returns the base URL of the endpoint, e.g. http://core-data:59880, using the Protocol of the endpoint
+// as the scheme and the default scheme if the Protocol is unknown
+func (e ServiceEndpoint) BaseURL(defaultScheme string) string {
+	scheme := e.Protocol
+	if scheme == "" {
+		scheme = defaultScheme
+	}
+
+	return scheme + "://" + e.HostPort()
 }
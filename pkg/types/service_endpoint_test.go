@@ -0,0 +1,34 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceEndpointBaseURL(t *testing.T) {
+	tests := []struct {
+		name             string
+		endpoint         ServiceEndpoint
+		expectedHostPort string
+		expectedBaseURL  string
+	}{
+		{"Host name", ServiceEndpoint{Host: "core-data", Port: 59880}, "core-data:59880", "http://core-data:59880"},
+		{"IPv4", ServiceEndpoint{Host: "10.0.0.1", Port: 59880}, "10.0.0.1:59880", "http://10.0.0.1:59880"},
+		{"IPv6", ServiceEndpoint{Host: "fd00::1", Port: 59880}, "[fd00::1]:59880", "http://[fd00::1]:59880"},
+		{"Bracketed IPv6", ServiceEndpoint{Host: "[fd00::1]", Port: 59880}, "[fd00::1]:59880", "http://[fd00::1]:59880"},
+		{"Endpoint protocol", ServiceEndpoint{Host: "core-data", Port: 59880, Protocol: "https"}, "core-data:59880", "https://core-data:59880"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedHostPort, testCase.endpoint.HostPort())
+			assert.Equal(t, testCase.expectedBaseURL, testCase.endpoint.BaseURL("http"))
+		})
+	}
+}
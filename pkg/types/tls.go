@@ -0,0 +1,102 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSConfig defines the optional TLS settings used to secure the connection to the registry service
+type TLSConfig struct {
+	// CAFile is the path to a PEM encoded CA bundle used to verify the registry service's certificate
+	CAFile string
+	// CAPEM is a PEM encoded CA bundle used to verify the registry service's certificate. Combined with CAFile if both are set
+	CAPEM string
+	// CertFile is the path to a PEM encoded client certificate presented to registry services requiring mutual TLS
+	CertFile string
+	// KeyFile is the path to the PEM encoded private key for CertFile
+	KeyFile string
+	// CertPEM is a PEM encoded client certificate presented to registry services requiring mutual TLS. Used instead of CertFile if set
+	CertPEM string
+	// KeyPEM is the PEM encoded private key for CertPEM
+	KeyPEM string
+	// ServerName overrides the host name used to verify the registry service's certificate
+	ServerName string
+	// InsecureSkipVerify disables verification of the registry service's certificate. Must only be used for testing
+	InsecureSkipVerify bool
+}
+
+// IsEnabled returns true when any of the TLS settings have been provided
+func (t TLSConfig) IsEnabled() bool {
+	return t.CAFile != "" || t.CAPEM != "" || t.CertFile != "" || t.KeyFile != "" || t.CertPEM != "" || t.KeyPEM != "" ||
+		t.ServerName != "" || t.InsecureSkipVerify
+}
+
+// BuildTLSConfig creates the crypto/tls configuration from the TLS settings.
+// Nil is returned when none of the TLS settings have been provided.
+func (t TLSConfig) BuildTLSConfig() (*tls.Config, error) {
+	if !t.IsEnabled() {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify, // #nosec G402 -- only set when explicitly configured
+	}
+
+	if t.CAFile != "" || t.CAPEM != "" {
+		pool := x509.NewCertPool()
+
+		if t.CAFile != "" {
+			caBytes, err := os.ReadFile(t.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA file %s: %v", t.CAFile, err)
+			}
+			if !pool.AppendCertsFromPEM(caBytes) {
+				return nil, fmt.Errorf("no valid certificates found in CA file %s", t.CAFile)
+			}
+		}
+
+		if t.CAPEM != "" && !pool.AppendCertsFromPEM([]byte(t.CAPEM)) {
+			return nil, fmt.Errorf("no valid certificates found in CA PEM")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	certificate, err := t.loadClientCertificate()
+	if err != nil {
+		return nil, err
+	}
+	if certificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+func (t TLSConfig) loadClientCertificate() (*tls.Certificate, error) {
+	switch {
+	case t.CertPEM != "" || t.KeyPEM != "":
+		certificate, err := tls.X509KeyPair([]byte(t.CertPEM), []byte(t.KeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate from PEM: %v", err)
+		}
+		return &certificate, nil
+	case t.CertFile != "" || t.KeyFile != "":
+		certificate, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate from %s and %s: %v", t.CertFile, t.KeyFile, err)
+		}
+		return &certificate, nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,126 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0600))
+
+	tests := []struct {
+		name          string
+		config        TLSConfig
+		expectNil     bool
+		expectRootCAs bool
+		expectError   bool
+	}{
+		{"Valid - not enabled", TLSConfig{}, true, false, false},
+		{"Valid - CA file", TLSConfig{CAFile: caFile}, false, true, false},
+		{"Valid - CA PEM", TLSConfig{CAPEM: string(caPEM)}, false, true, false},
+		{"Valid - server name only", TLSConfig{ServerName: "keeper"}, false, false, false},
+		{"Invalid - missing CA file", TLSConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")}, true, false, true},
+		{"Invalid - bad CA PEM", TLSConfig{CAPEM: "bogus"}, true, false, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			tlsConfig, err := testCase.config.BuildTLSConfig()
+			if testCase.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			if testCase.expectNil {
+				assert.Nil(t, tlsConfig)
+				return
+			}
+
+			require.NotNil(t, tlsConfig)
+			assert.Equal(t, testCase.config.ServerName, tlsConfig.ServerName)
+			assert.Equal(t, testCase.expectRootCAs, tlsConfig.RootCAs != nil)
+		})
+	}
+}
+
+func TestBuildTLSConfigClientCertificate(t *testing.T) {
+	certPEM, keyPEM := generateClientCertificate(t)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0600))
+
+	tests := []struct {
+		name        string
+		config      TLSConfig
+		expectError bool
+	}{
+		{"Valid - certificate files", TLSConfig{CertFile: certFile, KeyFile: keyFile}, false},
+		{"Valid - certificate PEM", TLSConfig{CertPEM: string(certPEM), KeyPEM: string(keyPEM)}, false},
+		{"Invalid - missing key file", TLSConfig{CertFile: certFile}, true},
+		{"Invalid - missing key PEM", TLSConfig{CertPEM: string(certPEM)}, true},
+		{"Invalid - key without certificate", TLSConfig{KeyPEM: string(keyPEM)}, true},
+		{"Invalid - mismatched PEM", TLSConfig{CertPEM: string(keyPEM), KeyPEM: string(certPEM)}, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			tlsConfig, err := testCase.config.BuildTLSConfig()
+			if testCase.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, tlsConfig.Certificates, 1)
+		})
+	}
+}
+
+func generateClientCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "registry-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM
+}
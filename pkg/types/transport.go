@@ -0,0 +1,58 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 10
+	defaultIdleConnTimeout     = 90 * time.Second
+)
+
+// HttpTransportConfig defines the optional tuning of the HTTP connections to the registry service, which are kept alive
+// and reused by all the requests of a client
+type HttpTransportConfig struct {
+	// MaxIdleConns is the maximum number of idle connections kept open. 100 is used if not set.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept open to the registry service. 10 is used if not set.
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is how long idle connections are kept open, e.g. "90s". 90 seconds is used if not set.
+	IdleConnTimeout string
+	// RequestTimeout is how long a request may take, including reading the response, e.g. "10s". Not limited if not set.
+	RequestTimeout string
+}
+
+// BuildTransport creates a transport with keep-alives enabled from the settings, based on http.DefaultTransport
+func (c HttpTransportConfig) BuildTransport() (*http.Transport, error) {
+	idleConnTimeout, err := parseOptionalDuration("idle connection timeout", c.IdleConnTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if idleConnTimeout == 0 {
+		idleConnTimeout = defaultIdleConnTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = defaultMaxIdleConns
+	if c.MaxIdleConns > 0 {
+		transport.MaxIdleConns = c.MaxIdleConns
+	}
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	if c.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = c.MaxIdleConnsPerHost
+	}
+	transport.IdleConnTimeout = idleConnTimeout
+
+	return transport, nil
+}
+
+func (c HttpTransportConfig) GetRequestTimeout() (time.Duration, error) {
+	return parseOptionalDuration("request timeout", c.RequestTimeout)
+}
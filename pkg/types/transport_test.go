@@ -0,0 +1,45 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTransport(t *testing.T) {
+	tests := []struct {
+		name                        string
+		config                      HttpTransportConfig
+		expectedMaxIdleConns        int
+		expectedMaxIdleConnsPerHost int
+		expectedIdleConnTimeout     time.Duration
+		expectError                 bool
+	}{
+		{"Defaults", HttpTransportConfig{}, 100, 10, 90 * time.Second, false},
+		{"Configured", HttpTransportConfig{MaxIdleConns: 20, MaxIdleConnsPerHost: 4, IdleConnTimeout: "30s"}, 20, 4, 30 * time.Second, false},
+		{"Invalid idle timeout", HttpTransportConfig{IdleConnTimeout: "bogus"}, 0, 0, 0, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			transport, err := testCase.config.BuildTransport()
+			if testCase.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedMaxIdleConns, transport.MaxIdleConns)
+			assert.Equal(t, testCase.expectedMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+			assert.Equal(t, testCase.expectedIdleConnTimeout, transport.IdleConnTimeout)
+			assert.False(t, transport.DisableKeepAlives)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+const (
+	modulePath = "github.com/edgexfoundry/go-mod-registry/v4"
+	moduleName = "go-mod-registry"
+	// unknownVersion is used when the module version isn't recorded in the binary, e.g. when built from the module itself
+	unknownVersion = "dev"
+)
+
+var moduleVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return unknownVersion
+	}
+
+	modules := append([]*debug.Module{&info.Main}, info.Deps...)
+	for _, module := range modules {
+		if module.Path != modulePath {
+			continue
+		}
+		if module.Replace != nil && module.Replace.Version != "" {
+			return module.Replace.Version
+		}
+		if module.Version != "" && module.Version != "(devel)" {
+			return module.Version
+		}
+	}
+
+	return unknownVersion
+})
+
+// GetUserAgent returns the User-Agent sent with the requests to the registry service, identifying the module version
+// and the service, e.g. "go-mod-registry/v4.0.0 (core-data)", unless overridden by the UserAgent
+func (config Config) GetUserAgent() string {
+	if config.UserAgent != "" {
+		return config.UserAgent
+	}
+
+	userAgent := fmt.Sprintf("%s/%s", moduleName, moduleVersion())
+	if config.ServiceKey != "" {
+		userAgent += fmt.Sprintf(" (%s)", config.ServiceKey)
+	}
+	return userAgent
+}
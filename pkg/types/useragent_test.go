@@ -0,0 +1,30 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserAgent(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{"Default", Config{ServiceKey: "core-data"}, "go-mod-registry/" + moduleVersion() + " (core-data)"},
+		{"No service key", Config{}, "go-mod-registry/" + moduleVersion()},
+		{"Overridden", Config{ServiceKey: "core-data", UserAgent: "edgex-core-data/4.0"}, "edgex-core-data/4.0"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.config.GetUserAgent())
+		})
+	}
+}
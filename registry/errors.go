@@ -0,0 +1,30 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+// Sentinel errors wrapped into the errors returned by the Client implementations. Use errors.Is to check for them.
+var (
+	// ErrServiceNotFound indicates the Registry has no registration for the requested service
+	ErrServiceNotFound = types.ErrServiceNotFound
+	// ErrNotRegistered indicates the service is not registered, either because it never registered or because it de-registered
+	ErrNotRegistered = types.ErrNotRegistered
+	// ErrServiceNotHealthy indicates the service is registered but its health check is not passing
+	ErrServiceNotHealthy = types.ErrServiceNotHealthy
+	// ErrServiceStarting indicates the service is registered but not healthy yet, while still within its health check grace period
+	ErrServiceStarting = types.ErrServiceStarting
+	// ErrAccessDenied indicates the Registry rejected the request due to missing or invalid credentials
+	ErrAccessDenied = types.ErrAccessDenied
+	// ErrInvalidServiceKey indicates the service key doesn't follow the configured ServiceKeyPolicy
+	ErrInvalidServiceKey = types.ErrInvalidServiceKey
+	// ErrInvalidRegistration indicates the registration details are malformed, e.g. the port is out of range
+	ErrInvalidRegistration = types.ErrInvalidRegistration
+	// ErrUnsupported indicates the Registry doesn't support the requested operation or settings
+	ErrUnsupported = types.ErrUnsupported
+)
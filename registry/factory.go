@@ -26,7 +26,8 @@ import (
 
 func NewRegistryClient(registryConfig types.Config) (Client, error) {
 
-	if registryConfig.Host == "" || registryConfig.Port == 0 {
+	// The port isn't used when connecting over a unix domain socket
+	if registryConfig.Host == "" || (registryConfig.Port == 0 && !registryConfig.IsUnixSocket()) {
 		return nil, fmt.Errorf("unable to create RegistryClient: registry host and/or port or serviceKey not set")
 	}
 
@@ -22,8 +22,12 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+	"github.com/edgexfoundry/go-mod-registry/v4/registry/mocks"
 )
 
+// The generated mock must stay in sync with the Client interface
+var _ Client = (*mocks.Client)(nil)
+
 var registryConfig = types.Config{
 	Host:        "localhost",
 	Port:        59890,
@@ -36,10 +40,13 @@ func TestNewRegistryClientKeeper(t *testing.T) {
 
 	registryConfig.Type = "keeper"
 
-	_, err := NewRegistryClient(registryConfig)
+	client, err := NewRegistryClient(registryConfig)
 	if assert.Nil(t, err, "New Registry client failed: ", err) == false {
 		t.Fatal()
 	}
+
+	// The heartbeat of the Keeper client must be stoppable, e.g. by Group.Quiesce
+	assert.Implements(t, (*Stopper)(nil), client)
 }
 
 func TestNewRegistryBogusType(t *testing.T) {
@@ -51,3 +58,15 @@ func TestNewRegistryBogusType(t *testing.T) {
 		t.Fatal()
 	}
 }
+
+func TestNewRegistryClientUnixSocket(t *testing.T) {
+	config := types.Config{
+		Protocol:   types.UnixProtocol,
+		Host:       "/run/edgex/keeper.sock",
+		Type:       "keeper",
+		ServiceKey: "edgex-registry-tests",
+	}
+
+	_, err := NewRegistryClient(config)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,136 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Stopper is implemented by clients running background workers which must be stopped when shutting down
+type Stopper interface {
+	// Stop stops the background workers of the client
+	Stop()
+}
+
+// GroupMember declares a client managed by a Group
+type GroupMember struct {
+	// Name identifies the member within the Group, typically the service key
+	Name string
+	// Client is the registry client of the member
+	Client Client
+	// DependsOn lists the names of the members this member depends on, which are quiesced after this member.
+	// Names which aren't members of the Group are ignored.
+	DependsOn []string
+	// Drain is called, if set, before the member is de-registered to let in-flight work complete
+	Drain func(ctx context.Context) error
+}
+
+// Group manages the registry clients of multiple services running in one process, so the whole process can be
+// shut down in a coordinated way
+type Group struct {
+	lock    sync.Mutex
+	members []GroupMember
+}
+
+// NewGroup creates an empty Group
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Add adds the member to the Group
+func (g *Group) Add(member GroupMember) error {
+	if member.Name == "" || member.Client == nil {
+		return errors.New("unable to add group member: name and client must be set")
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	for _, existing := range g.members {
+		if existing.Name == member.Name {
+			return fmt.Errorf("unable to add group member: %s already added", member.Name)
+		}
+	}
+
+	g.members = append(g.members, member)
+	return nil
+}
+
+// Quiesce drains, de-registers and stops the background workers of every member, one member at a time, with
+// members quiesced before the members they depend on. Failures don't stop the remaining members from being quiesced
+// and are all returned. Quiesce stops early if the context is done.
+func (g *Group) Quiesce(ctx context.Context) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	ordered, err := g.quiesceOrder()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, member := range ordered {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("quiesce stopped before %s: %w", member.Name, err))
+			break
+		}
+
+		if member.Drain != nil {
+			if err := member.Drain(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("failed to drain %s: %w", member.Name, err))
+			}
+		}
+
+		if err := member.Client.Unregister(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to de-register %s: %w", member.Name, err))
+		}
+
+		if stopper, ok := member.Client.(Stopper); ok {
+			stopper.Stop()
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// quiesceOrder sorts the members so that every member comes before the members it depends on, keeping the order in
+// which they were added otherwise
+func (g *Group) quiesceOrder() ([]GroupMember, error) {
+	dependents := make(map[string]int, len(g.members))
+	for _, member := range g.members {
+		dependents[member.Name] += 0
+		for _, dependency := range member.DependsOn {
+			dependents[dependency]++
+		}
+	}
+
+	done := make(map[string]bool, len(g.members))
+	ordered := make([]GroupMember, 0, len(g.members))
+	for len(ordered) < len(g.members) {
+		progressed := false
+		for _, member := range g.members {
+			if done[member.Name] || dependents[member.Name] > 0 {
+				continue
+			}
+
+			done[member.Name] = true
+			ordered = append(ordered, member)
+			progressed = true
+			for _, dependency := range member.DependsOn {
+				dependents[dependency]--
+			}
+		}
+
+		if !progressed {
+			return nil, errors.New("unable to quiesce group: members have circular dependencies")
+		}
+	}
+
+	return ordered, nil
+}
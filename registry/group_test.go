@@ -0,0 +1,88 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/registry/mocks"
+)
+
+type stoppableClient struct {
+	*mocks.Client
+	stopped bool
+}
+
+func (c *stoppableClient) Stop() {
+	c.stopped = true
+}
+
+func TestGroupQuiesce(t *testing.T) {
+	var order []string
+	newMember := func(name string, unregisterErr error, dependsOn ...string) GroupMember {
+		client := mocks.NewClient(t)
+		client.On("Unregister").Run(func(_ mock.Arguments) { order = append(order, name) }).Return(unregisterErr)
+		return GroupMember{Name: name, Client: client, DependsOn: dependsOn}
+	}
+
+	group := NewGroup()
+	require.NoError(t, group.Add(newMember("core-metadata", nil)))
+	require.NoError(t, group.Add(newMember("core-data", errors.New("failed"), "core-metadata")))
+	require.NoError(t, group.Add(newMember("core-command", nil, "core-metadata", "core-data", "security-proxy")))
+
+	stoppable := &stoppableClient{Client: mocks.NewClient(t)}
+	stoppable.On("Unregister").Run(func(_ mock.Arguments) { order = append(order, "app-rules-engine") }).Return(nil)
+	drained := false
+	require.NoError(t, group.Add(GroupMember{
+		Name:   "app-rules-engine",
+		Client: stoppable,
+		Drain: func(_ context.Context) error {
+			drained = true
+			return nil
+		},
+	}))
+
+	err := group.Quiesce(context.Background())
+	require.Error(t, err, "de-registration failure should be returned")
+	assert.Contains(t, err.Error(), "core-data")
+
+	assert.Equal(t, []string{"core-command", "app-rules-engine", "core-data", "core-metadata"}, order)
+	assert.True(t, drained)
+	assert.True(t, stoppable.stopped)
+}
+
+func TestGroupAddErrors(t *testing.T) {
+	group := NewGroup()
+
+	require.Error(t, group.Add(GroupMember{Name: "core-data"}))
+	require.NoError(t, group.Add(GroupMember{Name: "core-data", Client: mocks.NewClient(t)}))
+	require.Error(t, group.Add(GroupMember{Name: "core-data", Client: mocks.NewClient(t)}))
+}
+
+func TestGroupQuiesceCircularDependencies(t *testing.T) {
+	group := NewGroup()
+	require.NoError(t, group.Add(GroupMember{Name: "a", Client: mocks.NewClient(t), DependsOn: []string{"b"}}))
+	require.NoError(t, group.Add(GroupMember{Name: "b", Client: mocks.NewClient(t), DependsOn: []string{"a"}}))
+
+	require.Error(t, group.Quiesce(context.Background()))
+}
+
+func TestGroupQuiesceContextDone(t *testing.T) {
+	group := NewGroup()
+	require.NoError(t, group.Add(GroupMember{Name: "core-data", Client: mocks.NewClient(t)}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := group.Quiesce(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,54 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+// DefaultHealthConcurrency is the number of services whose health is fetched at once by FetchServicesHealth when no
+// concurrency is specified
+const DefaultHealthConcurrency = 8
+
+// ServiceHealth holds the health of a service fetched by FetchServicesHealth
+type ServiceHealth struct {
+	ServiceId string
+	// Checks holds the latest results of the health checks of the service
+	Checks []types.HealthCheckResult
+	// Err is the error which prevented fetching the health of the service, if any
+	Err error
+}
+
+// FetchServicesHealth fetches the health details of the services in parallel, with at most maxConcurrency requests to
+// the registry at once, rather than one service after another. DefaultHealthConcurrency is used if maxConcurrency isn't
+// positive. The results are in the same order as the service IDs, with failures reported per service.
+func FetchServicesHealth(client Client, serviceIds []string, maxConcurrency int) []ServiceHealth {
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultHealthConcurrency
+	}
+
+	results := make([]ServiceHealth, len(serviceIds))
+	slots := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i, serviceId := range serviceIds {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			checks, err := client.GetServiceHealthDetails(serviceId)
+			results[i] = ServiceHealth{ServiceId: serviceId, Checks: checks, Err: err}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
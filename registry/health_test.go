@@ -0,0 +1,78 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+	"github.com/edgexfoundry/go-mod-registry/v4/registry/mocks"
+)
+
+const healthTimeout = 5 * time.Second
+
+func TestFetchServicesHealth(t *testing.T) {
+	const maxConcurrency = 3
+
+	// The calls are held until maxConcurrency of them are in flight at once, which never happens if they're sequential
+	var inFlight, maxInFlight atomic.Int32
+	full := make(chan struct{})
+	var fullOnce sync.Once
+	client := mocks.NewClient(t)
+	client.On("GetServiceHealthDetails", mock.Anything).
+		Run(func(_ mock.Arguments) {
+			current := inFlight.Add(1)
+			for {
+				observed := maxInFlight.Load()
+				if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+			if current == maxConcurrency {
+				fullOnce.Do(func() { close(full) })
+			}
+			select {
+			case <-full:
+			case <-time.After(healthTimeout):
+			}
+			inFlight.Add(-1)
+		}).
+		Return(func(serviceId string) []types.HealthCheckResult {
+			if serviceId == "failing" {
+				return nil
+			}
+			return []types.HealthCheckResult{{Name: serviceId, Status: "UP"}}
+		}, func(serviceId string) error {
+			if serviceId == "failing" {
+				return types.ErrServiceNotFound
+			}
+			return nil
+		})
+
+	serviceIds := []string{"failing"}
+	for i := 0; i < 10; i++ {
+		serviceIds = append(serviceIds, fmt.Sprintf("service-%d", i))
+	}
+
+	results := FetchServicesHealth(client, serviceIds, maxConcurrency)
+	require.Len(t, results, len(serviceIds))
+	for i, result := range results {
+		assert.Equal(t, serviceIds[i], result.ServiceId)
+	}
+	assert.ErrorIs(t, results[0].Err, types.ErrServiceNotFound)
+	assert.Equal(t, []types.HealthCheckResult{{Name: "service-0", Status: "UP"}}, results[1].Checks)
+	assert.NoError(t, results[1].Err)
+
+	assert.Equal(t, int32(maxConcurrency), maxInFlight.Load(), "health should be fetched in parallel up to maxConcurrency")
+}
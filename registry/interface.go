@@ -20,25 +20,110 @@ import (
 	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
 )
 
+//go:generate mockery --name=Client --output=./mocks --outpkg=mocks --case=underscore --filename=Client.go
+
+// Client is the interface implemented by every registry backend. A testify mock of it is provided by the
+// registry/mocks package, which is versioned with this module, so consumers don't need to write their own.
 type Client interface {
-	// Registers the current service with Registry for discover and health check
-	Register() error
+	// Registers the current service with Registry for discover and health check. WithDryRun only checks the
+	// registration could be made.
+	Register(options ...types.RegisterOption) error
+
+	// Registers any service on its behalf, e.g. a sidecar process which can't register itself, independently of the
+	// current service
+	RegisterService(registration types.ServiceRegistration) error
+
+	// Registers the specified services, e.g. when a single process hosts multiple logical services. All services are
+	// attempted, and the ones which failed to register are reported with a *types.BatchRegistrationError.
+	RegisterAll(registrations []types.ServiceRegistration) error
+
+	// Updates the existing registration of the current service with its current details, keeping its history
+	UpdateRegister() error
+
+	// Changes the host and port the current service is registered with, updating the registration in place if registered
+	UpdateRegistration(newHost string, newPort int) error
 
 	// Un-registers the current service with Registry for discover and health check
 	Unregister() error
 
-	// Registers a
+	// Un-registers any service, e.g. to clean up the stale registration of a service which crashed without de-registering
+	UnregisterByServiceId(serviceKey string) error
+
+	// Registers a health check for the current service. Returns an error wrapping ErrUnsupported when the Registry is
+	// unable to perform the check.
 	RegisterCheck(id string, name string, notes string, url string, interval string) error
 
+	// Removes a health check added with RegisterCheck
+	UnregisterCheck(id string) error
+
 	// Simply checks if Registry is up and running at the configured URL
 	IsAlive() bool
 
-	// Gets the service endpoint information for the target ID from the Registry
+	// Checks if Registry is up and running at the configured URL, returning an error describing why if it isn't,
+	// along with the status details such as the latency and the HTTP status code
+	Status() (types.AliveStatus, error)
+
+	// Pings the Registry the specified number of times and reports the min, average and 95th percentile round-trip times
+	MeasureLatency(samples int) (types.LatencyReport, error)
+
+	// Gets the connectivity of the client to the Registry, such as when it last responded and the number of
+	// consecutive failures since, as observed from the calls made so far
+	ClientStats() types.ClientStats
+
+	// Gets the type and version of the Registry, along with how the client connects to it, for diagnostics
+	GetRegistryInfo() (types.RegistryInfo, error)
+
+	// Gets the client of the Registry backend, for the backend specific features not exposed by this module. It sends
+	// its requests with the configured authentication, TLS, retries and rate limits. For Keeper, this is the core-contracts
+	// interfaces.RegistryClient, e.g. client.UnderlyingClient().(interfaces.RegistryClient).
+	UnderlyingClient() any
+
+	// Gets the service endpoint information for the target ID from the Registry. The replicas of a service registered
+	// with an InstanceId are found by their service key, the healthy ones being preferred.
 	GetServiceEndpoint(serviceId string) (types.ServiceEndpoint, error)
 
-	// Gets all the service endpoints information from the Registry
+	// Gets all the service endpoints information from the Registry.
+	// When only part of the data could be retrieved, the available endpoints are returned with a *types.PartialResultError
 	GetAllServiceEndpoints() ([]types.ServiceEndpoint, error)
 
-	// Checks with the Registry if the target service is available, i.e. registered and healthy
+	// Gets a page of the service endpoints information from the Registry, along with the total number of endpoints.
+	// A negative limit gets all the endpoints from the offset.
+	GetAllServiceEndpointsPaged(offset int, limit int) ([]types.ServiceEndpoint, int, error)
+
+	// Gets the service endpoints information from the Registry, ordered and filtered as specified by the options
+	ListServiceEndpoints(options types.ListOptions) ([]types.ServiceEndpoint, error)
+
+	// Gets the information of the service endpoints with the status, e.g. UP or DOWN, from the Registry
+	GetServiceEndpointsByStatus(status string) ([]types.ServiceEndpoint, error)
+
+	// Gets the registrations of all the services from the Registry, ordered by service key, as a snapshot which can be
+	// serialized to JSON for backups and registered again with RegisterAll, e.g. to seed a test environment.
+	// When only part of the registrations could be retrieved, the available ones are returned with a *types.PartialResultError
+	ExportRegistrations() ([]types.ServiceRegistration, error)
+
+	// Gets the information of the service endpoints whose service ID matches the glob pattern, e.g. "device-*", from the
+	// Registry. The pattern syntax is the one of path.Match.
+	FindServiceEndpoints(pattern string) ([]types.ServiceEndpoint, error)
+
+	// Gets the latest results of the individual health checks of the target service from the Registry
+	GetServiceHealthDetails(serviceId string) ([]types.HealthCheckResult, error)
+
+	// Checks with the Registry if the target service is available, i.e. registered and healthy. When it isn't, the error
+	// wraps the sentinel error explaining why, which types.AvailabilityOf maps onto the same result for every Registry.
 	IsServiceAvailable(serviceId string) (bool, error)
+
+	// Subscribes to the health transitions, i.e. healthy to unhealthy and back, of the target service. The callback is called
+	// from a separate goroutine until the returned function is called, which must not be done from within the callback.
+	SubscribeHealthEvents(serviceId string, callback func(types.HealthEvent)) (func(), error)
+
+	// Subscribes to the services being added to or removed from the Registry. The callback is called from a separate
+	// goroutine until the returned function is called, which must not be done from within the callback.
+	SubscribeRegistrationEvents(callback func(types.RegistrationEvent)) (func(), error)
+
+	// Watches all the registrations, sending all the service endpoints on the updates channel whenever any of them changed,
+	// and failures on the errs channel if not nil, until the returned function is called
+	WatchAllServices(updates chan<- []types.ServiceEndpoint, errs chan<- error) (func(), error)
+
+	// Applies the changed configuration in place, only re-registering the current service when its registration details changed
+	Reconfigure(registryConfig types.Config) error
 }
@@ -0,0 +1,98 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/retry"
+)
+
+// LifecycleOptions holds the optional settings of ManageLifecycle
+type LifecycleOptions struct {
+	// CheckIds lists the IDs of the additional health checks added with RegisterCheck, which are removed when shutting down
+	CheckIds []string
+	// UnregisterRetry is the retry policy applied to every de-registration call. retry.DefaultPolicy() is used if not set.
+	UnregisterRetry retry.Policy
+	// Signals lists the signals which trigger the de-registration. SIGINT and SIGTERM are used if not set.
+	Signals []os.Signal
+	// Clock is the source of time for the retries. The system clock is used if not set.
+	Clock clock.Clock
+}
+
+// ManageLifecycle registers the current service and de-registers it, along with its additional health checks, once the
+// context is done or one of the signals is received, so services don't leave stale registrations behind.
+// De-registration is retried on failure and always attempted, even if the context is done. It isn't retried when the
+// Registry answered the service isn't registered or the access is denied, as retrying can't succeed.
+// The returned channel receives the result of the de-registration and is then closed.
+//
+// Until the de-registration starts, the signals are caught with signal.NotifyContext, so they no longer terminate the
+// process. The caller must exit once the result is received. The default behavior of the signals is restored when the
+// de-registration starts, so receiving one of them again terminates the process without waiting for the de-registration.
+func ManageLifecycle(ctx context.Context, client Client, options LifecycleOptions) (<-chan error, error) {
+	if err := client.Register(); err != nil {
+		return nil, fmt.Errorf("unable to manage service lifecycle: %w", err)
+	}
+
+	signals := options.Signals
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	signalCtx, stop := signal.NotifyContext(ctx, signals...)
+
+	policy := options.UnregisterRetry
+	if policy.MaxAttempts == 0 {
+		policy = retry.DefaultPolicy()
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		defer close(result)
+
+		<-signalCtx.Done()
+		stop()
+
+		// The context is done by now, so de-registration uses its own
+		unregister := func(call func() error) error {
+			return retry.Do(context.Background(), policy, options.Clock, func(_ context.Context) error {
+				err := call()
+				if err != nil && isPermanentUnregisterError(err) {
+					return retry.Permanent(err)
+				}
+				return err
+			})
+		}
+
+		var errs []error
+		for _, checkId := range options.CheckIds {
+			if err := unregister(func() error { return client.UnregisterCheck(checkId) }); err != nil {
+				errs = append(errs, fmt.Errorf("failed to remove health check %s: %w", checkId, err))
+			}
+		}
+		if err := unregister(client.Unregister); err != nil {
+			errs = append(errs, fmt.Errorf("failed to de-register service: %w", err))
+		}
+
+		result <- errors.Join(errs...)
+	}()
+
+	return result, nil
+}
+
+// isPermanentUnregisterError returns true when the Registry answered with a definitive error, which retrying the
+// de-registration can't resolve
+func isPermanentUnregisterError(err error) bool {
+	return errors.Is(err, ErrServiceNotFound) ||
+		errors.Is(err, ErrNotRegistered) ||
+		errors.Is(err, ErrAccessDenied) ||
+		errors.Is(err, ErrInvalidServiceKey)
+}
@@ -0,0 +1,86 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/retry"
+	"github.com/edgexfoundry/go-mod-registry/v4/registry/mocks"
+)
+
+const lifecycleTimeout = 5 * time.Second
+
+func TestManageLifecycle(t *testing.T) {
+	client := mocks.NewClient(t)
+	client.On("Register").Return(nil).Once()
+	client.On("UnregisterCheck", "db").Return(nil).Once()
+	client.On("Unregister").Return(errors.New("registry unavailable")).Once()
+	client.On("Unregister").Return(nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result, err := ManageLifecycle(ctx, client, LifecycleOptions{
+		CheckIds:        []string{"db"},
+		UnregisterRetry: retry.Policy{MaxAttempts: 2},
+	})
+	require.NoError(t, err)
+
+	cancel()
+	require.NoError(t, receiveResult(t, result), "de-registration should have been retried")
+}
+
+func TestManageLifecyclePermanentError(t *testing.T) {
+	client := mocks.NewClient(t)
+	client.On("Register").Return(nil).Once()
+	client.On("Unregister").Return(fmt.Errorf("failed to de-register: %w", ErrAccessDenied)).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result, err := ManageLifecycle(ctx, client, LifecycleOptions{UnregisterRetry: retry.Policy{MaxAttempts: 3}})
+	require.NoError(t, err)
+
+	cancel()
+	require.ErrorIs(t, receiveResult(t, result), ErrAccessDenied, "de-registration should not have been retried")
+}
+
+func TestManageLifecycleSignal(t *testing.T) {
+	client := mocks.NewClient(t)
+	client.On("Register").Return(nil).Once()
+	client.On("Unregister").Return(nil).Once()
+
+	result, err := ManageLifecycle(context.Background(), client, LifecycleOptions{Signals: []os.Signal{os.Interrupt}})
+	require.NoError(t, err)
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(os.Interrupt))
+
+	require.NoError(t, receiveResult(t, result))
+}
+
+func TestManageLifecycleRegisterError(t *testing.T) {
+	client := mocks.NewClient(t)
+	client.On("Register").Return(errors.New("failed")).Once()
+
+	_, err := ManageLifecycle(context.Background(), client, LifecycleOptions{})
+	require.Error(t, err)
+}
+
+func receiveResult(t *testing.T, result <-chan error) error {
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(lifecycleTimeout):
+		require.Fail(t, "de-registration did not complete")
+		return nil
+	}
+}
@@ -13,6 +13,66 @@ type Client struct {
 	mock.Mock
 }
 
+// ClientStats provides a mock function with given fields:
+func (_m *Client) ClientStats() types.ClientStats {
+	ret := _m.Called()
+
+	var r0 types.ClientStats
+	if rf, ok := ret.Get(0).(func() types.ClientStats); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(types.ClientStats)
+	}
+
+	return r0
+}
+
+// ExportRegistrations provides a mock function with given fields:
+func (_m *Client) ExportRegistrations() ([]types.ServiceRegistration, error) {
+	ret := _m.Called()
+
+	var r0 []types.ServiceRegistration
+	if rf, ok := ret.Get(0).(func() []types.ServiceRegistration); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.ServiceRegistration)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindServiceEndpoints provides a mock function with given fields: pattern
+func (_m *Client) FindServiceEndpoints(pattern string) ([]types.ServiceEndpoint, error) {
+	ret := _m.Called(pattern)
+
+	var r0 []types.ServiceEndpoint
+	if rf, ok := ret.Get(0).(func(string) []types.ServiceEndpoint); ok {
+		r0 = rf(pattern)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.ServiceEndpoint)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAllServiceEndpoints provides a mock function with given fields:
 func (_m *Client) GetAllServiceEndpoints() ([]types.ServiceEndpoint, error) {
 	ret := _m.Called()
@@ -36,6 +96,57 @@ func (_m *Client) GetAllServiceEndpoints() ([]types.ServiceEndpoint, error) {
 	return r0, r1
 }
 
+// GetAllServiceEndpointsPaged provides a mock function with given fields: offset, limit
+func (_m *Client) GetAllServiceEndpointsPaged(offset int, limit int) ([]types.ServiceEndpoint, int, error) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []types.ServiceEndpoint
+	if rf, ok := ret.Get(0).(func(int, int) []types.ServiceEndpoint); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.ServiceEndpoint)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int, int) int); ok {
+		r1 = rf(offset, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, int) error); ok {
+		r2 = rf(offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetRegistryInfo provides a mock function with given fields:
+func (_m *Client) GetRegistryInfo() (types.RegistryInfo, error) {
+	ret := _m.Called()
+
+	var r0 types.RegistryInfo
+	if rf, ok := ret.Get(0).(func() types.RegistryInfo); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(types.RegistryInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetServiceEndpoint provides a mock function with given fields: serviceId
 func (_m *Client) GetServiceEndpoint(serviceId string) (types.ServiceEndpoint, error) {
 	ret := _m.Called(serviceId)
@@ -57,6 +168,52 @@ func (_m *Client) GetServiceEndpoint(serviceId string) (types.ServiceEndpoint, e
 	return r0, r1
 }
 
+// GetServiceEndpointsByStatus provides a mock function with given fields: status
+func (_m *Client) GetServiceEndpointsByStatus(status string) ([]types.ServiceEndpoint, error) {
+	ret := _m.Called(status)
+
+	var r0 []types.ServiceEndpoint
+	if rf, ok := ret.Get(0).(func(string) []types.ServiceEndpoint); ok {
+		r0 = rf(status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.ServiceEndpoint)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceHealthDetails provides a mock function with given fields: serviceId
+func (_m *Client) GetServiceHealthDetails(serviceId string) ([]types.HealthCheckResult, error) {
+	ret := _m.Called(serviceId)
+
+	var r0 []types.HealthCheckResult
+	if rf, ok := ret.Get(0).(func(string) []types.HealthCheckResult); ok {
+		r0 = rf(serviceId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.HealthCheckResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(serviceId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsAlive provides a mock function with given fields:
 func (_m *Client) IsAlive() bool {
 	ret := _m.Called()
@@ -92,13 +249,91 @@ func (_m *Client) IsServiceAvailable(serviceId string) (bool, error) {
 	return r0, r1
 }
 
-// Register provides a mock function with given fields:
-func (_m *Client) Register() error {
-	ret := _m.Called()
+// ListServiceEndpoints provides a mock function with given fields: options
+func (_m *Client) ListServiceEndpoints(options types.ListOptions) ([]types.ServiceEndpoint, error) {
+	ret := _m.Called(options)
+
+	var r0 []types.ServiceEndpoint
+	if rf, ok := ret.Get(0).(func(types.ListOptions) []types.ServiceEndpoint); ok {
+		r0 = rf(options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.ServiceEndpoint)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.ListOptions) error); ok {
+		r1 = rf(options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MeasureLatency provides a mock function with given fields: samples
+func (_m *Client) MeasureLatency(samples int) (types.LatencyReport, error) {
+	ret := _m.Called(samples)
+
+	var r0 types.LatencyReport
+	if rf, ok := ret.Get(0).(func(int) types.LatencyReport); ok {
+		r0 = rf(samples)
+	} else {
+		r0 = ret.Get(0).(types.LatencyReport)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(samples)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reconfigure provides a mock function with given fields: registryConfig
+func (_m *Client) Reconfigure(registryConfig types.Config) error {
+	ret := _m.Called(registryConfig)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(types.Config) error); ok {
+		r0 = rf(registryConfig)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Register provides a mock function with given fields: options
+func (_m *Client) Register(options ...types.RegisterOption) error {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(...types.RegisterOption) error); ok {
+		r0 = rf(options...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegisterAll provides a mock function with given fields: registrations
+func (_m *Client) RegisterAll(registrations []types.ServiceRegistration) error {
+	ret := _m.Called(registrations)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]types.ServiceRegistration) error); ok {
+		r0 = rf(registrations)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -120,6 +355,103 @@ func (_m *Client) RegisterCheck(id string, name string, notes string, url string
 	return r0
 }
 
+// RegisterService provides a mock function with given fields: registration
+func (_m *Client) RegisterService(registration types.ServiceRegistration) error {
+	ret := _m.Called(registration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(types.ServiceRegistration) error); ok {
+		r0 = rf(registration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Status provides a mock function with given fields:
+func (_m *Client) Status() (types.AliveStatus, error) {
+	ret := _m.Called()
+
+	var r0 types.AliveStatus
+	if rf, ok := ret.Get(0).(func() types.AliveStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(types.AliveStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeHealthEvents provides a mock function with given fields: serviceId, callback
+func (_m *Client) SubscribeHealthEvents(serviceId string, callback func(types.HealthEvent)) (func(), error) {
+	ret := _m.Called(serviceId, callback)
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(string, func(types.HealthEvent)) func()); ok {
+		r0 = rf(serviceId, callback)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, func(types.HealthEvent)) error); ok {
+		r1 = rf(serviceId, callback)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeRegistrationEvents provides a mock function with given fields: callback
+func (_m *Client) SubscribeRegistrationEvents(callback func(types.RegistrationEvent)) (func(), error) {
+	ret := _m.Called(callback)
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(func(types.RegistrationEvent)) func()); ok {
+		r0 = rf(callback)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(func(types.RegistrationEvent)) error); ok {
+		r1 = rf(callback)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnderlyingClient provides a mock function with given fields:
+func (_m *Client) UnderlyingClient() any {
+	ret := _m.Called()
+
+	var r0 any
+	if rf, ok := ret.Get(0).(func() any); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(any)
+		}
+	}
+
+	return r0
+}
+
 // Unregister provides a mock function with given fields:
 func (_m *Client) Unregister() error {
 	ret := _m.Called()
@@ -134,6 +466,85 @@ func (_m *Client) Unregister() error {
 	return r0
 }
 
+// UnregisterByServiceId provides a mock function with given fields: serviceKey
+func (_m *Client) UnregisterByServiceId(serviceKey string) error {
+	ret := _m.Called(serviceKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(serviceKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnregisterCheck provides a mock function with given fields: id
+func (_m *Client) UnregisterCheck(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRegister provides a mock function with given fields:
+func (_m *Client) UpdateRegister() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRegistration provides a mock function with given fields: newHost, newPort
+func (_m *Client) UpdateRegistration(newHost string, newPort int) error {
+	ret := _m.Called(newHost, newPort)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = rf(newHost, newPort)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WatchAllServices provides a mock function with given fields: updates, errs
+func (_m *Client) WatchAllServices(updates chan<- []types.ServiceEndpoint, errs chan<- error) (func(), error) {
+	ret := _m.Called(updates, errs)
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(chan<- []types.ServiceEndpoint, chan<- error) func()); ok {
+		r0 = rf(updates, errs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(chan<- []types.ServiceEndpoint, chan<- error) error); ok {
+		r1 = rf(updates, errs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewClient interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,15 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+// WithDryRun makes Register check the registration could be made, without registering the service
+func WithDryRun() types.RegisterOption {
+	return types.WithDryRun()
+}
@@ -0,0 +1,70 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+)
+
+// PingDetailsQueryParam is the query parameter which, when true, adds the registry details to the ping response
+const PingDetailsQueryParam = "details"
+
+// ServiceStatus is the availability of a service as reported by the Registry
+type ServiceStatus struct {
+	Available    bool               `json:"available"`
+	Availability types.Availability `json:"availability"`
+	Message      string             `json:"message,omitempty"`
+}
+
+// PingResponse extends the standard ping response with the registry details of the service and its dependencies
+type PingResponse struct {
+	dtoCommon.PingResponse `json:",inline"`
+	Registration           *ServiceStatus           `json:"registration,omitempty"`
+	Dependencies           map[string]ServiceStatus `json:"dependencies,omitempty"`
+}
+
+// NewPingHandler returns a handler for the ping route compatible with the registry health checks. The standard ping
+// response is returned unless the details query parameter is true, in which case the availability of the service
+// itself and of its dependencies, as reported by the Registry, is added. The service is always reported as alive
+// since it is answering the request.
+func NewPingHandler(client Client, serviceKey string, dependencies ...string) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		response := PingResponse{
+			PingResponse: dtoCommon.NewPingResponse(serviceKey),
+		}
+
+		if details, _ := strconv.ParseBool(request.URL.Query().Get(PingDetailsQueryParam)); details {
+			registration := serviceStatus(client, serviceKey)
+			response.Registration = &registration
+
+			response.Dependencies = make(map[string]ServiceStatus, len(dependencies))
+			for _, dependency := range dependencies {
+				response.Dependencies[dependency] = serviceStatus(client, dependency)
+			}
+		}
+
+		writer.Header().Set(common.ContentType, common.ContentTypeJSON)
+		if err := json.NewEncoder(writer).Encode(response); err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func serviceStatus(client Client, serviceKey string) ServiceStatus {
+	available, err := client.IsServiceAvailable(serviceKey)
+	status := ServiceStatus{Available: available, Availability: types.AvailabilityOf(available, err)}
+	if err != nil {
+		status.Message = err.Error()
+	}
+	return status
+}
@@ -0,0 +1,71 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+	"github.com/edgexfoundry/go-mod-registry/v4/registry/mocks"
+)
+
+func TestPingHandler(t *testing.T) {
+	client := &mocks.Client{}
+	client.On("IsServiceAvailable", "app-rules-engine").Return(true, nil)
+	client.On("IsServiceAvailable", "core-data").Return(true, nil)
+	client.On("IsServiceAvailable", "core-metadata").Return(false, fmt.Errorf("core-metadata service not healthy: %w", types.ErrServiceNotHealthy))
+
+	handler := NewPingHandler(client, "app-rules-engine", "core-data", "core-metadata")
+
+	tests := []struct {
+		name          string
+		query         string
+		expectDetails bool
+	}{
+		{"Standard ping", "", false},
+		{"Details not requested", "?details=false", false},
+		{"Details requested", "?details=true", true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, common.ApiPingRoute+testCase.query, nil)
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			require.Equal(t, http.StatusOK, recorder.Code)
+			var response PingResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, common.ApiVersion, response.ApiVersion)
+			assert.Equal(t, "app-rules-engine", response.ServiceName)
+
+			if !testCase.expectDetails {
+				assert.Nil(t, response.Registration)
+				assert.Nil(t, response.Dependencies)
+				return
+			}
+
+			require.NotNil(t, response.Registration)
+			assert.True(t, response.Registration.Available)
+			assert.Equal(t, types.AvailabilityAvailable, response.Registration.Availability)
+			expected := map[string]ServiceStatus{
+				"core-data":     {Available: true, Availability: types.AvailabilityAvailable},
+				"core-metadata": {Available: false, Availability: types.AvailabilityNotHealthy, Message: "core-metadata service not healthy: service not healthy"},
+			}
+			assert.Equal(t, expected, response.Dependencies)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/clock"
+)
+
+const (
+	defaultReadinessPollInterval = time.Second
+	defaultReadinessTimeout      = time.Minute
+)
+
+// DependencyState is the state of a dependency reported by WaitForDependencies
+type DependencyState string
+
+const (
+	// DependencyWaiting indicates the dependency isn't available yet and will be checked again
+	DependencyWaiting DependencyState = "waiting"
+	// DependencyReady indicates the dependency is available
+	DependencyReady DependencyState = "ready"
+	// DependencyTimedOut indicates the dependency didn't become available within its timeout
+	DependencyTimedOut DependencyState = "timed out"
+)
+
+// Dependency declares a service which must be available before the current service starts
+type Dependency struct {
+	// ServiceId is the service key of the dependency
+	ServiceId string
+	// Timeout is how long to wait for the dependency. ReadinessOptions.Timeout is used if not set.
+	Timeout time.Duration
+}
+
+// DependencyEvent reports the progress of waiting for a dependency
+type DependencyEvent struct {
+	ServiceId string
+	State     DependencyState
+	// Attempt is the number of availability checks done so far
+	Attempt int
+	// Elapsed is how long the dependency has been waited for
+	Elapsed time.Duration
+	// Err is the reason the dependency isn't available, if any
+	Err error
+}
+
+// ReadinessOptions holds the optional settings of WaitForDependencies
+type ReadinessOptions struct {
+	// PollInterval is how often the availability of the dependencies is checked. One second is used if not set.
+	PollInterval time.Duration
+	// Timeout is how long to wait for the dependencies which don't set their own timeout. One minute is used if not set.
+	Timeout time.Duration
+	// OnEvent is called with the progress of every dependency. Calls are never concurrent.
+	OnEvent func(event DependencyEvent)
+	// Clock is the source of time for the polling and timeouts. The system clock is used if not set.
+	Clock clock.Clock
+}
+
+// WaitForDependencies waits in parallel for all the dependencies to be available, i.e. registered and healthy,
+// each within its own timeout. An error is returned for every dependency which isn't available in time, or for all
+// the pending ones if the context is done first.
+func WaitForDependencies(ctx context.Context, client Client, dependencies []Dependency, options ReadinessOptions) error {
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaultReadinessPollInterval
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultReadinessTimeout
+	}
+	if options.Clock == nil {
+		options.Clock = clock.New()
+	}
+
+	var eventLock sync.Mutex
+	notify := func(event DependencyEvent) {
+		if options.OnEvent == nil {
+			return
+		}
+
+		eventLock.Lock()
+		defer eventLock.Unlock()
+		options.OnEvent(event)
+	}
+
+	errs := make([]error, len(dependencies))
+	var wg sync.WaitGroup
+	for i, dependency := range dependencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = waitForDependency(ctx, client, dependency, options, notify)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func waitForDependency(ctx context.Context, client Client, dependency Dependency, options ReadinessOptions,
+	notify func(DependencyEvent)) error {
+	timeout := dependency.Timeout
+	if timeout <= 0 {
+		timeout = options.Timeout
+	}
+
+	start := options.Clock.Now()
+	for attempt := 1; ; attempt++ {
+		available, err := client.IsServiceAvailable(dependency.ServiceId)
+		elapsed := options.Clock.Since(start)
+		if available {
+			notify(DependencyEvent{ServiceId: dependency.ServiceId, State: DependencyReady, Attempt: attempt, Elapsed: elapsed})
+			return nil
+		}
+
+		if err == nil {
+			err = errors.New("service not available")
+		}
+
+		remaining := timeout - elapsed
+		if remaining <= 0 {
+			notify(DependencyEvent{ServiceId: dependency.ServiceId, State: DependencyTimedOut, Attempt: attempt, Elapsed: elapsed, Err: err})
+			return fmt.Errorf("dependency %s not available after %v: %w", dependency.ServiceId, timeout, err)
+		}
+
+		notify(DependencyEvent{ServiceId: dependency.ServiceId, State: DependencyWaiting, Attempt: attempt, Elapsed: elapsed, Err: err})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for dependency %s: %w", dependency.ServiceId, ctx.Err())
+		case <-options.Clock.After(min(options.PollInterval, remaining)):
+		}
+	}
+}
@@ -0,0 +1,75 @@
+//
+// Copyright (C) 2024 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-registry/v4/pkg/types"
+	"github.com/edgexfoundry/go-mod-registry/v4/registry/mocks"
+)
+
+func TestWaitForDependencies(t *testing.T) {
+	client := mocks.NewClient(t)
+	client.On("IsServiceAvailable", "core-metadata").Return(true, nil).Once()
+	client.On("IsServiceAvailable", "core-data").Return(false, types.ErrServiceStarting).Once()
+	client.On("IsServiceAvailable", "core-data").Return(true, nil).Once()
+
+	var events []DependencyEvent
+	err := WaitForDependencies(context.Background(), client,
+		[]Dependency{{ServiceId: "core-metadata"}, {ServiceId: "core-data"}},
+		ReadinessOptions{
+			PollInterval: time.Millisecond,
+			OnEvent:      func(event DependencyEvent) { events = append(events, event) },
+		})
+	require.NoError(t, err)
+
+	states := make(map[string][]DependencyState)
+	for _, event := range events {
+		states[event.ServiceId] = append(states[event.ServiceId], event.State)
+	}
+	assert.Equal(t, []DependencyState{DependencyReady}, states["core-metadata"])
+	assert.Equal(t, []DependencyState{DependencyWaiting, DependencyReady}, states["core-data"])
+}
+
+func TestWaitForDependenciesTimeout(t *testing.T) {
+	client := mocks.NewClient(t)
+	client.On("IsServiceAvailable", "core-metadata").Return(true, nil).Once()
+	client.On("IsServiceAvailable", "core-data").Return(false, types.ErrServiceNotHealthy)
+
+	var lastEvent DependencyEvent
+	err := WaitForDependencies(context.Background(), client,
+		[]Dependency{{ServiceId: "core-metadata"}, {ServiceId: "core-data", Timeout: 20 * time.Millisecond}},
+		ReadinessOptions{
+			PollInterval: 5 * time.Millisecond,
+			OnEvent: func(event DependencyEvent) {
+				if event.ServiceId == "core-data" {
+					lastEvent = event
+				}
+			},
+		})
+	require.ErrorIs(t, err, types.ErrServiceNotHealthy)
+	assert.Contains(t, err.Error(), "core-data")
+	assert.NotContains(t, err.Error(), "core-metadata")
+	assert.Equal(t, DependencyTimedOut, lastEvent.State)
+	assert.Greater(t, lastEvent.Attempt, 1)
+}
+
+func TestWaitForDependenciesCanceled(t *testing.T) {
+	client := mocks.NewClient(t)
+	client.On("IsServiceAvailable", "core-data").Return(false, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := WaitForDependencies(ctx, client, []Dependency{{ServiceId: "core-data"}}, ReadinessOptions{
+		OnEvent: func(event DependencyEvent) { cancel() },
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}